@@ -33,7 +33,7 @@ func datasourceOrganizationRead(ctx context.Context, d *schema.ResourceData, m i
 
 	var diags diag.Diagnostics
 
-	organization, err := c.GetOrganization()
+	organization, err := c.GetOrganization(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
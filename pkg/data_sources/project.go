@@ -0,0 +1,643 @@
+package data_sources
+
+import (
+	"context"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	dbtBlocks = []string{
+		"dbt_cloud_ide",
+		"dbt_git",
+	}
+	wareHouseTypes = []string{
+		"bigquery",
+		"clickhouse",
+		"databricks",
+		"postgres",
+		"redshift",
+		"snowflake",
+		"trino",
+	}
+)
+
+// Only the settings returned by Lightdash are exposed, the credentials and
+// warehouse users are never read back
+var projectSchema = map[string]*schema.Schema{
+	"project_uuid": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  "UUID of the project to look up, conflicts with `name`",
+		ExactlyOneOf: []string{"project_uuid", "name"},
+	},
+	"name": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  "Name of the project to look up, must be unique within the organization, conflicts with `project_uuid`",
+		ExactlyOneOf: []string{"project_uuid", "name"},
+	},
+	"organization_uuid": &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "UUID of the organization the project belongs to",
+	},
+	"type": &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Type of project, either DEFAULT or DEVELOPMENT",
+	},
+	"dbt_version": &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "dbt version",
+	},
+	"dbt_git": &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "dbt project hosted in a git repository, secrets are not included",
+		Elem:        dbtGitResource,
+	},
+	"dbt_cloud_ide": &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "dbt project hosted in dbt Cloud, secrets are not included",
+		Elem:        dbtCloudIDEResource,
+	},
+	"snowflake": &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Snowflake warehouse connection, secrets are not included",
+		Elem:        snowflakeResource,
+	},
+	"databricks": &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Databricks warehouse connection, secrets are not included",
+		Elem:        databricksResource,
+	},
+	"bigquery": &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "BigQuery warehouse connection, secrets are not included",
+		Elem:        bigqueryResource,
+	},
+	"postgres": &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Postgres warehouse connection, secrets are not included",
+		Elem:        postgresResource,
+	},
+	"redshift": &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Redshift warehouse connection, secrets are not included",
+		Elem:        redshiftResource,
+	},
+	"trino": &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Trino warehouse connection, secrets are not included",
+		Elem:        trinoResource,
+	},
+	"clickhouse": &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "ClickHouse warehouse connection, secrets are not included",
+		Elem:        clickhouseResource,
+	},
+}
+
+var dbtGitResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"type": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Git provider",
+		},
+		"repository": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Repository name",
+		},
+		"branch": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Branch used",
+		},
+		"project_sub_path": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Sub path to the project in the repo",
+		},
+		"host_domain": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Host domain of the repo",
+		},
+		"username": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Username used by 'bitbucket'",
+		},
+		"organization": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Organization used by 'azure_devops'",
+		},
+		"project": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Project used by 'azure_devops'",
+		},
+	},
+}
+
+var dbtCloudIDEResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"environment_id": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "dbt Cloud environment ID",
+		},
+		"discovery_api_endpoint": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "dbt Cloud discovery API endpoint",
+		},
+		"tags": &schema.Schema{
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "dbt Cloud tags used to filter the models",
+		},
+	},
+}
+
+var snowflakeResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"account": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Account identifier",
+		},
+		"role": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Role used to connect",
+		},
+		"database": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Database connected to",
+		},
+		"warehouse": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Warehouse used",
+		},
+		"schema": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Schema connected to",
+		},
+		"client_session_keep_alive": &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Client session keep alive param",
+		},
+		"threads": &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of threads used",
+		},
+		"authentication_type": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Authentication method",
+		},
+		"query_tag": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Query tag added to all queries",
+		},
+		"access_url": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Custom access URL",
+		},
+	},
+}
+
+var databricksResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"server_host_name": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Server host name",
+		},
+		"http_path": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "HTTP path of the default SQL warehouse or cluster",
+		},
+		"authentication_type": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Authentication method",
+		},
+		"oauth_client_id": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Client ID of the service principal",
+		},
+		"catalog": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Catalog name",
+		},
+		"schema": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Schema name",
+		},
+		"start_of_week": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "First day of the week",
+		},
+		"compute": &schema.Schema{
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Additional SQL warehouses or clusters",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": &schema.Schema{
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Name shown when selecting the compute",
+					},
+					"http_path": &schema.Schema{
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "HTTP path of the SQL warehouse or cluster",
+					},
+				},
+			},
+		},
+	},
+}
+
+var bigqueryResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"project": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "GCP project ID queries run in",
+		},
+		"dataset": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Default dataset",
+		},
+		"location": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Location of the dataset",
+		},
+		"priority": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Query priority",
+		},
+		"timeout_seconds": &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Query timeout in seconds",
+		},
+		"retries": &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of times a failed query is retried",
+		},
+		"maximum_bytes_billed": &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Maximum bytes a query can bill",
+		},
+	},
+}
+
+var postgresResource = &schema.Resource{
+	Schema: postgresConnectionSchema(),
+}
+
+var redshiftResource = &schema.Resource{
+	Schema: redshiftConnectionSchema(),
+}
+
+// Postgres and Redshift share the same connection settings
+func postgresConnectionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Host name of the database server",
+		},
+		"port": &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Port of the database server",
+		},
+		"dbname": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Database name to connect to",
+		},
+		"schema": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Schema to connect to",
+		},
+		"sslmode": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "SSL mode",
+		},
+		"keepalives_idle": &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Seconds of inactivity before sending a keepalive",
+		},
+		"use_ssh_tunnel": &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the connection goes through an SSH tunnel",
+		},
+		"ssh_tunnel_host": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Host name of the SSH tunnel",
+		},
+		"ssh_tunnel_port": &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Port of the SSH tunnel",
+		},
+		"ssh_tunnel_user": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "User to connect to the SSH tunnel as",
+		},
+		"ssh_tunnel_public_key": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Public key generated by Lightdash, to be added to the SSH tunnel's authorized keys",
+		},
+	}
+}
+
+func redshiftConnectionSchema() map[string]*schema.Schema {
+	redshiftSchema := postgresConnectionSchema()
+	redshiftSchema["ra3_node"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the cluster uses RA3 nodes",
+	}
+	return redshiftSchema
+}
+
+var trinoResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"host": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Host name of the coordinator",
+		},
+		"port": &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Port of the coordinator",
+		},
+		"catalog": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Catalog connected to",
+		},
+		"schema": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Schema connected to",
+		},
+		"http_scheme": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "HTTP scheme used",
+		},
+	},
+}
+
+var clickhouseResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"host": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Host name of the server",
+		},
+		"port": &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "HTTP(S) port of the server",
+		},
+		"schema": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Database used as the schema",
+		},
+		"secure": &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the connection uses HTTPS",
+		},
+		"timeout_seconds": &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Query timeout in seconds",
+		},
+	},
+}
+
+func DatasourceProject() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceProjectRead,
+		Schema:      projectSchema,
+	}
+}
+
+func datasourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	var project *lightdash.Project
+	var err error
+	if projectUUID := d.Get("project_uuid").(string); projectUUID != "" {
+		project, err = c.GetProject(ctx, projectUUID)
+	} else {
+		project, err = c.GetProjectByName(ctx, d.Get("name").(string))
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("project_uuid", project.ProjectUUID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", project.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("organization_uuid", project.OrganisationUUID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("type", project.Type); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("dbt_version", project.DbtVersion); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only the blocks matching the connection types are set
+	dbtBlock := "dbt_git"
+	if project.DbtConnection.Type == "dbt_cloud_ide" {
+		dbtBlock = "dbt_cloud_ide"
+	}
+	for _, block := range dbtBlocks {
+		value := []interface{}{}
+		if block == dbtBlock {
+			value = append(value, flattenDbtConnection(project.DbtConnection))
+		}
+		if err := d.Set(block, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	for _, block := range wareHouseTypes {
+		value := []interface{}{}
+		if block == project.WarehouseConnection.Type {
+			value = append(value, flattenWarehouseConnection(project.WarehouseConnection))
+		}
+		if err := d.Set(block, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(project.ProjectUUID)
+
+	return diags
+}
+
+func flattenDbtConnection(dbtConnection lightdash.DbtConnection) map[string]interface{} {
+	if dbtConnection.Type == "dbt_cloud_ide" {
+		return map[string]interface{}{
+			"environment_id":         dbtConnection.EnvironmentID,
+			"discovery_api_endpoint": dbtConnection.DiscoveryAPIEndpoint,
+			"tags":                   dbtConnection.Tags,
+		}
+	}
+	return map[string]interface{}{
+		"type":             dbtConnection.Type,
+		"repository":       dbtConnection.Repository,
+		"branch":           dbtConnection.Branch,
+		"project_sub_path": dbtConnection.ProjectSubPath,
+		"host_domain":      dbtConnection.HostDomain,
+		"username":         dbtConnection.Username,
+		"organization":     dbtConnection.Organization,
+		"project":          dbtConnection.Project,
+	}
+}
+
+func flattenWarehouseConnection(warehouseConnection lightdash.WarehouseConnection) map[string]interface{} {
+	switch warehouseConnection.Type {
+	case "snowflake":
+		return map[string]interface{}{
+			"account":                   warehouseConnection.Account,
+			"role":                      warehouseConnection.Role,
+			"database":                  warehouseConnection.Database,
+			"warehouse":                 warehouseConnection.Warehouse,
+			"schema":                    warehouseConnection.Schema,
+			"client_session_keep_alive": warehouseConnection.ClientSessionKeepAlive,
+			"threads":                   warehouseConnection.Threads,
+			"authentication_type":       warehouseConnection.AuthenticationType,
+			"query_tag":                 warehouseConnection.QueryTag,
+			"access_url":                warehouseConnection.AccessURL,
+		}
+	case "databricks":
+		compute := []interface{}{}
+		for _, c := range warehouseConnection.Compute {
+			compute = append(compute, map[string]interface{}{
+				"name":      c.Name,
+				"http_path": c.HTTPPath,
+			})
+		}
+		// Lightdash calls the Databricks schema the database
+		return map[string]interface{}{
+			"server_host_name":    warehouseConnection.ServerHostName,
+			"http_path":           warehouseConnection.HTTPPath,
+			"authentication_type": warehouseConnection.AuthenticationType,
+			"oauth_client_id":     warehouseConnection.OAuthClientID,
+			"catalog":             warehouseConnection.Catalog,
+			"schema":              warehouseConnection.Database,
+			"compute":             compute,
+			"start_of_week":       lightdash.WeekDayName(warehouseConnection.StartOfWeek),
+		}
+	case "bigquery":
+		return map[string]interface{}{
+			"project":              warehouseConnection.Project,
+			"dataset":              warehouseConnection.Dataset,
+			"location":             warehouseConnection.Location,
+			"priority":             warehouseConnection.Priority,
+			"timeout_seconds":      warehouseConnection.TimeoutSeconds,
+			"retries":              warehouseConnection.Retries,
+			"maximum_bytes_billed": warehouseConnection.MaximumBytesBilled,
+		}
+	case "postgres", "redshift":
+		block := map[string]interface{}{
+			"host":                  warehouseConnection.Host,
+			"port":                  warehouseConnection.Port,
+			"dbname":                warehouseConnection.DBName,
+			"schema":                warehouseConnection.Schema,
+			"sslmode":               warehouseConnection.SSLMode,
+			"keepalives_idle":       warehouseConnection.KeepalivesIdle,
+			"use_ssh_tunnel":        warehouseConnection.UseSSHTunnel,
+			"ssh_tunnel_host":       warehouseConnection.SSHTunnelHost,
+			"ssh_tunnel_port":       warehouseConnection.SSHTunnelPort,
+			"ssh_tunnel_user":       warehouseConnection.SSHTunnelUser,
+			"ssh_tunnel_public_key": warehouseConnection.SSHTunnelPublicKey,
+		}
+		if warehouseConnection.Type == "redshift" {
+			block["ra3_node"] = warehouseConnection.RA3Node
+		}
+		return block
+	case "trino":
+		// Lightdash calls the Trino catalog the database name
+		return map[string]interface{}{
+			"host":        warehouseConnection.Host,
+			"port":        warehouseConnection.Port,
+			"catalog":     warehouseConnection.DBName,
+			"schema":      warehouseConnection.Schema,
+			"http_scheme": warehouseConnection.HTTPScheme,
+		}
+	case "clickhouse":
+		return map[string]interface{}{
+			"host":            warehouseConnection.Host,
+			"port":            warehouseConnection.Port,
+			"schema":          warehouseConnection.Schema,
+			"secure":          warehouseConnection.Secure,
+			"timeout_seconds": warehouseConnection.TimeoutSeconds,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package data_sources
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var projectsSchema = map[string]*schema.Schema{
+	"name_regex": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Regular expression the project names must match, all projects are returned when not set",
+		ValidateFunc: validation.StringIsValidRegExp,
+	},
+	"projects": &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Projects in the organization",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"project_uuid": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "UUID of the project",
+				},
+				"name": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Project name",
+				},
+				"type": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Type of project, either DEFAULT or DEVELOPMENT",
+				},
+				"warehouse_type": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Type of warehouse the project connects to",
+				},
+			},
+		},
+	},
+}
+
+func DatasourceProjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceProjectsRead,
+		Schema:      projectsSchema,
+	}
+}
+
+func datasourceProjectsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	nameRegex := d.Get("name_regex").(string)
+	nameMatcher, err := regexp.Compile(nameRegex)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	projects, err := c.GetProjects(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	organization, err := c.GetOrganization(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	results := []interface{}{}
+	for _, project := range projects {
+		if !nameMatcher.MatchString(project.Name) {
+			continue
+		}
+		results = append(results, map[string]interface{}{
+			"project_uuid":   project.ProjectUUID,
+			"name":           project.Name,
+			"type":           project.Type,
+			"warehouse_type": project.WarehouseType,
+		})
+	}
+
+	if err := d.Set("projects", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organization.UUID)
+
+	return diags
+}
@@ -0,0 +1,101 @@
+package data_sources
+
+import (
+	"context"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var userSchema = map[string]*schema.Schema{
+	"user_uuid": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  "UUID of the user to look up, conflicts with `email`",
+		ExactlyOneOf: []string{"user_uuid", "email"},
+	},
+	"email": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  "Email address of the user to look up, case-insensitive, conflicts with `user_uuid`",
+		ExactlyOneOf: []string{"user_uuid", "email"},
+	},
+	"first_name": &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "First name of the user",
+	},
+	"last_name": &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Last name of the user",
+	},
+	"organization_uuid": &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "UUID of the organization the user belongs to",
+	},
+	"role": &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Role of the user within the organization",
+	},
+	"is_active": &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the user is active, false for deactivated users and pending invites",
+	},
+}
+
+func DatasourceUser() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceUserRead,
+		Schema:      userSchema,
+	}
+}
+
+func datasourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	var user *lightdash.User
+	var err error
+	if userUUID := d.Get("user_uuid").(string); userUUID != "" {
+		user, err = c.GetUser(ctx, userUUID)
+	} else {
+		user, err = c.GetUserByEmail(ctx, d.Get("email").(string))
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("user_uuid", user.UserUUID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("email", user.Email); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("first_name", user.FirstName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("last_name", user.LastName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("organization_uuid", user.OrganizationUUID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role", user.Role); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_active", user.IsActive); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(user.UserUUID)
+
+	return diags
+}
@@ -0,0 +1,136 @@
+package data_sources
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var usersSchema = map[string]*schema.Schema{
+	"role": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Only return users with this role, one of member/ viewer/ interactive_viewer/ editor/ developer/ admin",
+		ValidateFunc: validation.StringInSlice(lightdash.OrganizationRoles, false),
+	},
+	"is_active": &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Only return active users when true, or inactive users and pending invites when false, all users are returned when not set",
+	},
+	"email_domain_regex": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Regular expression the domain of the users' email addresses must match, e.g. `^example\\.com$`",
+		ValidateFunc: validation.StringIsValidRegExp,
+	},
+	"users": &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Users of the organization matching the filters",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"user_uuid": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "UUID of the user",
+				},
+				"email": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Email address of the user",
+				},
+				"first_name": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "First name of the user",
+				},
+				"last_name": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Last name of the user",
+				},
+				"role": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Role of the user within the organization",
+				},
+				"is_active": &schema.Schema{
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether the user is active",
+				},
+			},
+		},
+	},
+}
+
+func DatasourceUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceUsersRead,
+		Schema:      usersSchema,
+	}
+}
+
+func datasourceUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	role := d.Get("role").(string)
+	domainMatcher, err := regexp.Compile(d.Get("email_domain_regex").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// A bool left out of the config reads as false, so check the raw config
+	// to tell an unset filter apart from is_active = false
+	isActive := cty.NullVal(cty.Bool)
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() {
+		isActive = rawConfig.GetAttr("is_active")
+	}
+
+	users, err := c.GetUsers(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	organization, err := c.GetOrganization(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	results := []interface{}{}
+	for _, user := range users {
+		if role != "" && user.Role != role {
+			continue
+		}
+		if !isActive.IsNull() && user.IsActive != isActive.True() {
+			continue
+		}
+		if !domainMatcher.MatchString(user.Email[strings.LastIndex(user.Email, "@")+1:]) {
+			continue
+		}
+		results = append(results, map[string]interface{}{
+			"user_uuid":  user.UserUUID,
+			"email":      user.Email,
+			"first_name": user.FirstName,
+			"last_name":  user.LastName,
+			"role":       user.Role,
+			"is_active":  user.IsActive,
+		})
+	}
+
+	if err := d.Set("users", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organization.UUID)
+
+	return diags
+}
@@ -1,14 +1,19 @@
 package lightdash
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"strings"
+	"net/http/cookiejar"
+	"sync"
 	"time"
 )
 
+const ID_DELIMITER = ":"
+
+// Client is safe to share between goroutines, its settings must not be
+// changed once it has been created
 type Client struct {
 	URL        string
 	HTTPClient *http.Client
@@ -16,7 +21,20 @@ type Client struct {
 	Password   string
 	Token      string
 	ApiURL     string
-	Cookies    []*http.Cookie
+
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// Guards logging in again, see relogin
+	sessionMu sync.Mutex
+	session   int
+
+	// Holds a value for every request in flight, when limited
+	requestSlots chan struct{}
+
+	// Sent with every request, see WithExtraHeaders
+	extraHeaders http.Header
 }
 
 type LoginRequest struct {
@@ -39,22 +57,33 @@ type LoginResults struct {
 }
 
 type LoginResponse struct {
-	Status  string       `json:"status`
-	Results LoginResults `json:"results`
+	Status  string       `json:"status"`
+	Results LoginResults `json:"results"`
 }
 
-// TODO: Convert to use a session
 // TODO: Convert to 2 separate clients
-func NewClient(url *string, username *string, password *string, token *string) (*Client, error) {
+func NewClient(ctx context.Context, url *string, username *string, password *string, token *string, options ...ClientOption) (*Client, error) {
+	// The session cookie set when logging in is kept in the jar, and sent
+	// with every request
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
 	c := Client{
-		URL:        *url,
-		HTTPClient: &http.Client{Timeout: 10 * time.Second},
-		ApiURL:     fmt.Sprintf("%s/api/v1", *url),
+		URL:          *url,
+		HTTPClient:   &http.Client{Timeout: DefaultRequestTimeout, Jar: jar},
+		ApiURL:       fmt.Sprintf("%s/api/v1", *url),
+		MaxRetries:   DefaultMaxRetries,
+		RetryWaitMin: DefaultRetryWaitMin,
+		RetryWaitMax: DefaultRetryWaitMax,
+	}
+	for _, option := range options {
+		option(&c)
 	}
 
 	if (url != nil) && (token != nil) {
 		c.Token = *token
-		req, err := http.NewRequest("GET", fmt.Sprintf("%s/org/projects", c.ApiURL), nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/org/projects", c.ApiURL), nil)
 		if err != nil {
 			return nil, err
 		}
@@ -67,58 +96,93 @@ func NewClient(url *string, username *string, password *string, token *string) (
 	}
 
 	if (url != nil) && (username != nil) && (password != nil) {
-		loginRequest := LoginRequest{
-			Email:    *username,
-			Password: *password,
-		}
-		loginRequestData, err := json.Marshal(loginRequest)
-		if err != nil {
+		c.Username = *username
+		c.Password = *password
+		if err := c.login(ctx); err != nil {
 			return nil, err
 		}
-
-		req, err := http.NewRequest("POST", fmt.Sprintf("%s/login", c.ApiURL), strings.NewReader(string(loginRequestData)))
-		if err != nil {
-			return nil, err
-		}
-
-		body, err, cookies := c.doRequest(req)
-
-		lr := LoginResponse{}
-		err = json.Unmarshal(body, &lr)
-		if err != nil {
-			return nil, err
-		}
-
-		c.Cookies = cookies
 	}
 
 	return &c, nil
 }
 
 func (c *Client) doRequest(req *http.Request) ([]byte, error, []*http.Cookie) {
-	req.Header.Add("Accept", "application/json")
-	req.Header.Add("Content-Type", "application/json")
-	if c.Token != "" {
-		req.Header.Add("Authorization", fmt.Sprintf("ApiKey %s", c.Token))
-	} else {
-		for _, cookie := range c.Cookies {
-			req.AddCookie(cookie)
+	c.setHeaders(req)
+
+	session := c.currentSession()
+	body, err, cookies := c.send(req)
+	if c.usesSession() && isUnauthorized(err) {
+		// The session has most likely expired, log in again and resend the
+		// request once
+		if err := c.relogin(req.Context(), session); err != nil {
+			return nil, err, nil
 		}
+		if err := rewindBody(req); err != nil {
+			return nil, err, nil
+		}
+		return c.send(req)
 	}
-	res, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err, nil
-	}
-	defer res.Body.Close()
 
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err, nil
-	}
+	return body, err, cookies
+}
 
-	if (res.StatusCode != http.StatusOK) && (res.StatusCode != 201) {
-		return nil, fmt.Errorf("%s url: %s, status: %d, body: %s", req.Method, req.URL, res.StatusCode, body), nil
+func (c *Client) setHeaders(req *http.Request) {
+	for name, values := range c.extraHeaders {
+		req.Header[name] = values
 	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("ApiKey %s", c.Token))
+	}
+}
 
-	return body, err, res.Cookies()
+// send makes the request, retrying it when it is rate limited or fails in a
+// way that is safe to retry
+func (c *Client) send(req *http.Request) ([]byte, error, []*http.Cookie) {
+	ctx := newLogContext(req.Context())
+	for attempt := 0; ; attempt++ {
+		// The HTTP client adds the cookies from the jar to the request itself,
+		// drop those sent by a previous attempt so that a new session is used
+		req.Header.Del("Cookie")
+		// The slot is only held while the request is in flight, and not while
+		// waiting to retry it
+		if err := c.acquireRequestSlot(req.Context()); err != nil {
+			return nil, err, nil
+		}
+		c.logRequest(ctx, req, attempt)
+		start := time.Now()
+		res, err := c.HTTPClient.Do(req)
+		var body []byte
+		if err == nil {
+			body, err = ioutil.ReadAll(res.Body)
+			res.Body.Close()
+		}
+		c.releaseRequestSlot()
+		logResponse(ctx, req, res, body, time.Since(start), err)
+
+		if attempt < c.MaxRetries && shouldRetry(req, res, err) {
+			if err := rewindBody(req); err != nil {
+				return nil, err, nil
+			}
+			wait := c.retryWait(attempt, res)
+			logRetry(ctx, req, attempt+1, wait)
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err(), nil
+			case <-time.After(wait):
+			}
+			continue
+		}
+
+		if err != nil {
+			return nil, err, nil
+		}
+
+		if (res.StatusCode != http.StatusOK) && (res.StatusCode != 201) {
+			return nil, newAPIError(req, res.StatusCode, body), nil
+		}
+
+		return body, nil, res.Cookies()
+	}
 }
@@ -1,25 +1,97 @@
 package lightdash
 
+// Days of the week in the order of Lightdash's WeekDay enum, the API takes
+// the index of the day
+var WeekDays = []string{
+	"monday",
+	"tuesday",
+	"wednesday",
+	"thursday",
+	"friday",
+	"saturday",
+	"sunday",
+}
+
 type DbtConnection struct {
-	Type                string `json:"type"`
-	Repository          string `json:"repository"`
-	Branch              string `json:"branch"`
-	ProjectSubPath      string `json:"project_sub_path"`
-	HostDomain          string `json:"host_domain"`
-	PersonalAccessToken string `json:"personal_access_token,omitempty"`
+	Type                 string   `json:"type"`
+	Repository           string   `json:"repository,omitempty"`
+	Branch               string   `json:"branch,omitempty"`
+	ProjectSubPath       string   `json:"project_sub_path,omitempty"`
+	HostDomain           string   `json:"host_domain,omitempty"`
+	PersonalAccessToken  string   `json:"personal_access_token,omitempty"`
+	Username             string   `json:"username,omitempty"`
+	Organization         string   `json:"organization,omitempty"`
+	Project              string   `json:"project,omitempty"`
+	APIKey               string   `json:"api_key,omitempty"`
+	EnvironmentID        string   `json:"environment_id,omitempty"`
+	DiscoveryAPIEndpoint string   `json:"discovery_api_endpoint,omitempty"`
+	Tags                 []string `json:"tags,omitempty"`
 }
 
 type WarehouseConnection struct {
-	Type                   string `json:"type"`
-	Account                string `json:"account,omitempty"`
-	Role                   string `json:"role,omitempty"`
-	Database               string `json:"database,omitempty"`
-	Warehouse              string `json:"warehouse,omitempty"`
-	Schema                 string `json:"schema,omitempty"`
-	ClientSessionKeepAlive bool   `json:"clientSessionKeepAlive,omitempty"`
-	Threads                int    `json:"threads,omitempty"`
-	ServerHostName         string `json:"serverHostName,omitempty"`
-	HTTPPath               string `json:"httpPath,omitempty"`
-	PersonalAccessToken    string `json:"personalAccessToken,omitempty"`
-	Catalog                string `json:"catalog,omitempty"`
+	Type                   string                 `json:"type"`
+	Account                string                 `json:"account,omitempty"`
+	Role                   string                 `json:"role,omitempty"`
+	Database               string                 `json:"database,omitempty"`
+	Warehouse              string                 `json:"warehouse,omitempty"`
+	Schema                 string                 `json:"schema,omitempty"`
+	ClientSessionKeepAlive bool                   `json:"clientSessionKeepAlive,omitempty"`
+	Threads                int                    `json:"threads,omitempty"`
+	ServerHostName         string                 `json:"serverHostName,omitempty"`
+	HTTPPath               string                 `json:"httpPath,omitempty"`
+	PersonalAccessToken    string                 `json:"personalAccessToken,omitempty"`
+	Catalog                string                 `json:"catalog,omitempty"`
+	Project                string                 `json:"project,omitempty"`
+	Dataset                string                 `json:"dataset,omitempty"`
+	Location               string                 `json:"location,omitempty"`
+	Priority               string                 `json:"priority,omitempty"`
+	TimeoutSeconds         int                    `json:"timeoutSeconds,omitempty"`
+	Retries                int                    `json:"retries,omitempty"`
+	MaximumBytesBilled     int                    `json:"maximumBytesBilled,omitempty"`
+	KeyfileContents        map[string]interface{} `json:"keyfileContents,omitempty"`
+	Host                   string                 `json:"host,omitempty"`
+	Port                   int                    `json:"port,omitempty"`
+	User                   string                 `json:"user,omitempty"`
+	Password               string                 `json:"password,omitempty"`
+	DBName                 string                 `json:"dbname,omitempty"`
+	SSLMode                string                 `json:"sslmode,omitempty"`
+	KeepalivesIdle         int                    `json:"keepalivesIdle,omitempty"`
+	UseSSHTunnel           bool                   `json:"useSshTunnel,omitempty"`
+	SSHTunnelHost          string                 `json:"sshTunnelHost,omitempty"`
+	SSHTunnelPort          int                    `json:"sshTunnelPort,omitempty"`
+	SSHTunnelUser          string                 `json:"sshTunnelUser,omitempty"`
+	SSHTunnelPublicKey     string                 `json:"sshTunnelPublicKey,omitempty"`
+	RA3Node                bool                   `json:"ra3Node,omitempty"`
+	HTTPScheme             string                 `json:"http_scheme,omitempty"`
+	Secure                 bool                   `json:"secure,omitempty"`
+	PrivateKey             string                 `json:"privateKey,omitempty"`
+	PrivateKeyPass         string                 `json:"privateKeyPass,omitempty"`
+	AuthenticationType     string                 `json:"authenticationType,omitempty"`
+	QueryTag               string                 `json:"queryTag,omitempty"`
+	AccessURL              string                 `json:"accessUrl,omitempty"`
+	Compute                []DatabricksCompute    `json:"compute,omitempty"`
+	OAuthClientID          string                 `json:"oauthClientId,omitempty"`
+	OAuthClientSecret      string                 `json:"oauthClientSecret,omitempty"`
+	StartOfWeek            *int                   `json:"startOfWeek,omitempty"`
+}
+
+type DatabricksCompute struct {
+	Name     string `json:"name"`
+	HTTPPath string `json:"httpPath"`
+}
+
+func WeekDayIndex(weekDay string) *int {
+	for i, day := range WeekDays {
+		if day == weekDay {
+			return &i
+		}
+	}
+	return nil
+}
+
+func WeekDayName(weekDay *int) string {
+	if weekDay == nil || *weekDay < 0 || *weekDay >= len(WeekDays) {
+		return ""
+	}
+	return WeekDays[*weekDay]
 }
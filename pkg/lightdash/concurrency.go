@@ -0,0 +1,35 @@
+package lightdash
+
+import "context"
+
+// WithMaxConcurrentRequests limits how many requests the client sends at
+// the same time, across all the goroutines sharing it, 0 means no limit
+func WithMaxConcurrentRequests(maxConcurrentRequests int) ClientOption {
+	return func(c *Client) {
+		c.requestSlots = nil
+		if maxConcurrentRequests > 0 {
+			c.requestSlots = make(chan struct{}, maxConcurrentRequests)
+		}
+	}
+}
+
+// acquireRequestSlot blocks until fewer than the maximum number of requests
+// are in flight, or the context is done
+func (c *Client) acquireRequestSlot(ctx context.Context) error {
+	if c.requestSlots == nil {
+		return nil
+	}
+	select {
+	case c.requestSlots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) releaseRequestSlot() {
+	if c.requestSlots == nil {
+		return
+	}
+	<-c.requestSlots
+}
@@ -0,0 +1,155 @@
+package lightdash
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testConcurrencyServer holds every request to a project for a while, and
+// records the most requests it had in flight at once
+type testConcurrencyServer struct {
+	*httptest.Server
+
+	inFlight    int32
+	maxInFlight int32
+	requests    int32
+	rateLimit   bool
+	release     chan struct{}
+}
+
+func newTestConcurrencyServer(t *testing.T) *testConcurrencyServer {
+	s := &testConcurrencyServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testConcurrencyServer) handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/api/v1/org/projects" {
+		w.Write([]byte(`{"status":"ok","results":[]}`))
+		return
+	}
+
+	inFlight := atomic.AddInt32(&s.inFlight, 1)
+	defer atomic.AddInt32(&s.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&s.maxInFlight)
+		if inFlight <= max || atomic.CompareAndSwapInt32(&s.maxInFlight, max, inFlight) {
+			break
+		}
+	}
+
+	// Every other one of the first 20 requests is rate limited, so that
+	// retries run alongside the other requests
+	if n := atomic.AddInt32(&s.requests, 1); s.rateLimit && n <= 20 && n%2 == 0 {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+
+	if s.release != nil {
+		<-s.release
+	} else {
+		time.Sleep(10 * time.Millisecond)
+	}
+	w.Write([]byte(`{"status":"ok","results":{"projectUuid":"abc","name":"Project"}}`))
+}
+
+func (s *testConcurrencyServer) client(t *testing.T, options ...ClientOption) *Client {
+	token := "test-token"
+	c, err := NewClient(context.Background(), &s.URL, nil, nil, &token, options...)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+	return c
+}
+
+func getProjectsInParallel(c *Client, count int) []error {
+	var wg sync.WaitGroup
+	errs := make([]error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = c.GetProject(context.Background(), "abc")
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	cases := map[string]struct {
+		maxConcurrentRequests int
+		expectedMaxInFlight   int32
+	}{
+		"limited":   {3, 3},
+		"unlimited": {0, 20},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := newTestConcurrencyServer(t)
+			c := server.client(t, WithMaxConcurrentRequests(tc.maxConcurrentRequests))
+
+			// Hold the requests until as many as expected are in flight, which
+			// would never happen if the limit was not enforced
+			server.release = make(chan struct{})
+			go func() {
+				for atomic.LoadInt32(&server.inFlight) < tc.expectedMaxInFlight {
+					time.Sleep(time.Millisecond)
+				}
+				close(server.release)
+			}()
+
+			for _, err := range getProjectsInParallel(c, 20) {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			}
+			if max := atomic.LoadInt32(&server.maxInFlight); max != tc.expectedMaxInFlight {
+				t.Errorf("expected at most %d requests in flight, got %d", tc.expectedMaxInFlight, max)
+			}
+		})
+	}
+}
+
+func TestMaxConcurrentRequestsWithRetries(t *testing.T) {
+	server := newTestConcurrencyServer(t)
+	server.rateLimit = true
+	c := server.client(t, WithMaxConcurrentRequests(4), WithRetries(10, time.Millisecond, 5*time.Millisecond))
+
+	for _, err := range getProjectsInParallel(c, 40) {
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+	if max := atomic.LoadInt32(&server.maxInFlight); max > 4 {
+		t.Errorf("expected at most 4 requests in flight, got %d", max)
+	}
+}
+
+func TestMaxConcurrentRequestsCancelledWhileWaiting(t *testing.T) {
+	server := newTestConcurrencyServer(t)
+	server.release = make(chan struct{})
+	t.Cleanup(func() { close(server.release) })
+	c := server.client(t, WithMaxConcurrentRequests(1))
+
+	// Take the only slot with a request the server never answers
+	go c.GetProject(context.Background(), "abc")
+	for atomic.LoadInt32(&server.inFlight) < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetProject(ctx, "abc"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to be exceeded waiting for a slot, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package lightdash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNotFound is wrapped by the errors of lookups that search a listing,
+// where a missing object isn't reported with a 404
+var ErrNotFound = errors.New("not found")
+
+// APIError is returned when the API answers with a status outside of 200/201
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	// Name and Message are parsed from the error body returned by Lightdash,
+	// e.g. NotFoundError, and are empty when the body isn't JSON
+	Name    string
+	Message string
+	Body    string
+}
+
+type apiErrorResponse struct {
+	Status string `json:"status"`
+	Error  struct {
+		StatusCode int    `json:"statusCode"`
+		Name       string `json:"name"`
+		Message    string `json:"message"`
+	} `json:"error"`
+}
+
+func newAPIError(req *http.Request, statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		Method:     req.Method,
+		URL:        maskURL(req.URL),
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+
+	errorResponse := apiErrorResponse{}
+	if err := json.Unmarshal(body, &errorResponse); err == nil {
+		apiErr.Name = errorResponse.Error.Name
+		apiErr.Message = errorResponse.Error.Message
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	if e.Name == "" && e.Message == "" {
+		return fmt.Sprintf("%s url: %s, status: %d, body: %s", e.Method, e.URL, e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("%s url: %s, status: %d, %s: %s", e.Method, e.URL, e.StatusCode, e.Name, e.Message)
+}
+
+// IsNotFound reports whether the object asked for doesn't exist, either
+// because the API answered with a 404 or it was missing from a listing
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusNotFound
+	}
+	return errors.Is(err, ErrNotFound)
+}
@@ -0,0 +1,48 @@
+package lightdash
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://lightdash.example.com/api/v1/projects/abc", nil)
+
+	apiErr := newAPIError(req, http.StatusNotFound, []byte(`{"status":"error","error":{"statusCode":404,"name":"NotFoundError","message":"Project not found"}}`))
+	if apiErr.Name != "NotFoundError" || apiErr.Message != "Project not found" {
+		t.Errorf("expected the error body to be parsed, got %#v", apiErr)
+	}
+	expected := "GET url: https://lightdash.example.com/api/v1/projects/abc, status: 404, NotFoundError: Project not found"
+	if apiErr.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, apiErr.Error())
+	}
+
+	apiErr = newAPIError(req, http.StatusBadGateway, []byte("Bad Gateway"))
+	expected = "GET url: https://lightdash.example.com/api/v1/projects/abc, status: 502, body: Bad Gateway"
+	if apiErr.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, apiErr.Error())
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	cases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"404":             {&APIError{StatusCode: http.StatusNotFound}, true},
+		"wrapped 404":     {fmt.Errorf("reading project: %w", &APIError{StatusCode: http.StatusNotFound}), true},
+		"403":             {&APIError{StatusCode: http.StatusForbidden}, false},
+		"missing listing": {fmt.Errorf("User %w UUID %s", ErrNotFound, "abc"), true},
+		"other error":     {fmt.Errorf("connection refused"), false},
+		"nil":             {nil, false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if actual := IsNotFound(tc.err); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package lightdash
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -26,8 +27,8 @@ type InviteLinkResponse struct {
 	Status  string     `json:"status"`
 }
 
-func (c *Client) GetInviteLink(inviteCode string) (*InviteLink, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/invite-links/%s", c.ApiURL, inviteCode), nil)
+func (c *Client) GetInviteLink(ctx context.Context, inviteCode string) (*InviteLink, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/invite-links/%s", c.ApiURL, inviteCode), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +46,7 @@ func (c *Client) GetInviteLink(inviteCode string) (*InviteLink, error) {
 	return &inviteLinkResponse.Results, nil
 }
 
-func (c *Client) CreateInviteLink(email string) (*InviteLink, error) {
+func (c *Client) CreateInviteLink(ctx context.Context, email string) (*InviteLink, error) {
 	newInviteLinkRequest := InviteLinkRequest{
 		ExpiresAt: "2099-01-01T23:59:59Z",
 		Email:     email,
@@ -54,7 +55,7 @@ func (c *Client) CreateInviteLink(email string) (*InviteLink, error) {
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/invite-links", c.ApiURL), strings.NewReader(string(newInviteLinkRequestData)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/invite-links", c.ApiURL), strings.NewReader(string(newInviteLinkRequestData)))
 	if err != nil {
 		return nil, err
 	}
@@ -73,8 +74,8 @@ func (c *Client) CreateInviteLink(email string) (*InviteLink, error) {
 	return &inviteLinkResponse.Results, nil
 }
 
-func (c *Client) DeleteInviteLink(inviteCode string) (string, error) {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/invite-links/%s", c.ApiURL, inviteCode), nil)
+func (c *Client) DeleteInviteLink(ctx context.Context, inviteCode string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/invite-links/%s", c.ApiURL, inviteCode), nil)
 	if err != nil {
 		return "", err
 	}
@@ -93,8 +94,8 @@ func (c *Client) DeleteInviteLink(inviteCode string) (string, error) {
 	return inviteLinkResponse.Status, nil
 }
 
-func (c *Client) DeleteAllInviteLinks() (string, error) {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/invite-links", c.ApiURL), nil)
+func (c *Client) DeleteAllInviteLinks(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/invite-links", c.ApiURL), nil)
 	if err != nil {
 		return "", err
 	}
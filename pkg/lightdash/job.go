@@ -0,0 +1,65 @@
+package lightdash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	JobStatusStarted = "STARTED"
+	JobStatusRunning = "RUNNING"
+	JobStatusDone    = "DONE"
+	JobStatusError   = "ERROR"
+)
+
+type DbtLog struct {
+	Level string `json:"level"`
+	Msg   string `json:"msg"`
+}
+
+type JobStep struct {
+	StepType    string   `json:"stepType"`
+	StepStatus  string   `json:"stepStatus"`
+	StepLabel   string   `json:"stepLabel"`
+	StepError   string   `json:"stepError,omitempty"`
+	StepDbtLogs []DbtLog `json:"stepDbtLogs,omitempty"`
+	StartedAt   string   `json:"startedAt,omitempty"`
+	UpdatedAt   string   `json:"updatedAt,omitempty"`
+}
+
+type Job struct {
+	JobUUID     string    `json:"jobUuid"`
+	ProjectUUID string    `json:"projectUuid"`
+	JobStatus   string    `json:"jobStatus"`
+	JobType     string    `json:"jobType"`
+	CreatedAt   string    `json:"createdAt"`
+	UpdatedAt   string    `json:"updatedAt"`
+	Steps       []JobStep `json:"steps"`
+}
+
+type JobResponse struct {
+	Results Job    `json:"results"`
+	Status  string `json:"status"`
+}
+
+func (c *Client) GetJob(ctx context.Context, jobUUID string) (*Job, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/jobs/%s", c.ApiURL, jobUUID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	jobResponse := JobResponse{}
+	err = json.Unmarshal(body, &jobResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jobResponse.Results, nil
+}
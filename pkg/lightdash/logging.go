@@ -0,0 +1,202 @@
+package lightdash
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Requests are logged under their own subsystem, whose level can be set on
+// its own with TF_LOG_PROVIDER_LIGHTDASH_API
+const logSubsystem = "api"
+
+const maskedValue = "***"
+
+// Headers which carry credentials, and are never logged
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+}
+
+// Fields of request and response bodies which are never logged, compared
+// case-insensitively, along with any field containing password, secret or token
+var sensitiveFields = map[string]bool{
+	"apikey":              true,
+	"api_key":             true,
+	"invitecode":          true,
+	"keyfilecontents":     true,
+	"privatekey":          true,
+	"privatekeypass":      true,
+	"sshtunnelprivatekey": true,
+	"sslcert":             true,
+	"sslkey":              true,
+	"sslrootcert":         true,
+}
+
+var sensitiveFieldFragments = []string{
+	"password",
+	"secret",
+	"token",
+}
+
+// Path segments followed by a secret, e.g. /invite-links/{inviteCode}
+var sensitivePathPrefixes = map[string]bool{
+	"invite-links": true,
+}
+
+func newLogContext(ctx context.Context) context.Context {
+	return tflog.NewSubsystem(ctx, logSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_LIGHTDASH", logSubsystem))
+}
+
+func (c *Client) logRequest(ctx context.Context, req *http.Request, attempt int) {
+	fields := map[string]interface{}{
+		"method":  req.Method,
+		"url":     maskURL(req.URL),
+		"attempt": attempt + 1,
+	}
+	tflog.SubsystemDebug(ctx, logSubsystem, "Sending request", fields)
+
+	body := []byte{}
+	if req.GetBody != nil {
+		if reader, err := req.GetBody(); err == nil {
+			body, _ = ioutil.ReadAll(reader)
+			reader.Close()
+		}
+	}
+	tflog.SubsystemTrace(ctx, logSubsystem, "Request details", map[string]interface{}{
+		"method":  req.Method,
+		"url":     maskURL(req.URL),
+		"headers": maskHeaders(req.Header, c.extraHeaders),
+		"body":    maskBody(body),
+	})
+}
+
+func logResponse(ctx context.Context, req *http.Request, res *http.Response, body []byte, latency time.Duration, err error) {
+	fields := map[string]interface{}{
+		"method":     req.Method,
+		"url":        maskURL(req.URL),
+		"latency_ms": latency.Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = maskError(req, err)
+		tflog.SubsystemDebug(ctx, logSubsystem, "Request failed", fields)
+		return
+	}
+
+	fields["status"] = res.StatusCode
+	if requestID := res.Header.Get("X-Request-Id"); requestID != "" {
+		fields["request_id"] = requestID
+	}
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received response", fields)
+
+	fields["headers"] = maskHeaders(res.Header, nil)
+	fields["body"] = maskBody(body)
+	tflog.SubsystemTrace(ctx, logSubsystem, "Response details", fields)
+}
+
+func logRetry(ctx context.Context, req *http.Request, nextAttempt int, wait time.Duration) {
+	tflog.SubsystemDebug(ctx, logSubsystem, "Retrying request", map[string]interface{}{
+		"method":  req.Method,
+		"url":     maskURL(req.URL),
+		"attempt": nextAttempt + 1,
+		"wait_ms": wait.Milliseconds(),
+	})
+}
+
+// maskHeaders masks the headers carrying credentials, along with the extra
+// headers set on the client which may well do too
+func maskHeaders(header http.Header, extraHeaders http.Header) map[string]string {
+	masked := map[string]string{}
+	for name, values := range header {
+		masked[name] = strings.Join(values, ", ")
+		if _, ok := extraHeaders[name]; ok {
+			masked[name] = maskedValue
+		}
+	}
+	for _, name := range sensitiveHeaders {
+		if _, ok := header[name]; ok {
+			masked[name] = maskedValue
+		}
+	}
+	return masked
+}
+
+// maskURL masks the secrets in the path of a URL, along with any password
+func maskURL(u *url.URL) string {
+	segments := strings.Split(u.EscapedPath(), "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if sensitivePathPrefixes[segments[i]] && segments[i+1] != "" {
+			segments[i+1] = maskedValue
+		}
+	}
+	masked := *u
+	masked.RawPath = strings.Join(segments, "/")
+	masked.Path, _ = url.PathUnescape(masked.RawPath)
+	return masked.Redacted()
+}
+
+// maskError masks the URL quoted in the errors returned by the HTTP client
+func maskError(req *http.Request, err error) string {
+	if urlErr, ok := err.(*url.Error); ok {
+		masked := *urlErr
+		masked.URL = maskURL(req.URL)
+		return masked.Error()
+	}
+	return err.Error()
+}
+
+// maskBody replaces the values of sensitive fields anywhere in a JSON body,
+// bodies which aren't JSON are logged as they are
+func maskBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+	masked, err := json.Marshal(maskValue(value))
+	if err != nil {
+		return maskedValue
+	}
+	return string(masked)
+}
+
+func maskValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, fieldValue := range v {
+			if isSensitiveField(key) && fieldValue != nil {
+				v[key] = maskedValue
+			} else {
+				v[key] = maskValue(fieldValue)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = maskValue(v[i])
+		}
+	}
+	return value
+}
+
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	if sensitiveFields[name] {
+		return true
+	}
+	for _, fragment := range sensitiveFieldFragments {
+		if strings.Contains(name, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package lightdash
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -16,8 +17,8 @@ type OrganizationResponse struct {
 	Status  string       `json:"status"`
 }
 
-func (c *Client) GetOrganization() (*Organization, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/org", c.ApiURL), nil)
+func (c *Client) GetOrganization(ctx context.Context) (*Organization, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/org", c.ApiURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package lightdash
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -33,13 +34,13 @@ type UpdateProjectRequest struct {
 	WarehouseConnection WarehouseConnection `json:"warehouseConnection"`
 }
 
-type jobResults struct {
-	jobUuid string `json:"jobUuid"`
+type JobResults struct {
+	JobUUID string `json:"jobUuid"`
 }
 
 type UpdateProjectResponse struct {
 	Status  string     `json:"status"`
-	Results jobResults `json:"results"`
+	Results JobResults `json:"results"`
 }
 
 type CreateProjectResponseResults struct {
@@ -62,8 +63,50 @@ type ProjectsResponse struct {
 	Status  string    `json:"status"`
 }
 
-func (c *Client) GetProject(projectUUID string) (*Project, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/projects/%s", c.ApiURL, projectUUID), nil)
+// Summary of a project as listed for the organization
+type OrganizationProject struct {
+	ProjectUUID         string `json:"projectUuid"`
+	Name                string `json:"name"`
+	Type                string `json:"type"`
+	WarehouseType       string `json:"warehouseType,omitempty"`
+	UpstreamProjectUUID string `json:"upstreamProjectUuid,omitempty"`
+}
+
+type OrganizationProjectsResponse struct {
+	Results []OrganizationProject `json:"results"`
+	Status  string                `json:"status"`
+}
+
+type ProjectMember struct {
+	UserUUID    string `json:"userUuid"`
+	ProjectUUID string `json:"projectUuid,omitempty"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+	FirstName   string `json:"firstName,omitempty"`
+	LastName    string `json:"lastName,omitempty"`
+}
+
+type CreateProjectAccessRequest struct {
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	SendEmail bool   `json:"sendEmail"`
+}
+
+type UpdateProjectAccessRequest struct {
+	Role string `json:"role"`
+}
+
+type ProjectAccessResponse struct {
+	Results []ProjectMember `json:"results"`
+	Status  string          `json:"status"`
+}
+
+type ProjectAccessStatusResponse struct {
+	Status string `json:"status"`
+}
+
+func (c *Client) GetProject(ctx context.Context, projectUUID string) (*Project, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/projects/%s", c.ApiURL, projectUUID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +125,50 @@ func (c *Client) GetProject(projectUUID string) (*Project, error) {
 	return &projectResponse.Results, nil
 }
 
-func (c *Client) CreateProject(organisationUUID, name, projectType, dbtVersion string, dbtConnection DbtConnection, warehouseConnection WarehouseConnection) (*Project, error) {
+func (c *Client) GetProjects(ctx context.Context) ([]OrganizationProject, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/org/projects", c.ApiURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	projectsResponse := OrganizationProjectsResponse{}
+	err = json.Unmarshal(body, &projectsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return projectsResponse.Results, nil
+}
+
+// Looks up a project by name, failing if the name is not unique
+func (c *Client) GetProjectByName(ctx context.Context, name string) (*Project, error) {
+	projects, err := c.GetProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var projectUUIDs []string
+	for _, project := range projects {
+		if project.Name == name {
+			projectUUIDs = append(projectUUIDs, project.ProjectUUID)
+		}
+	}
+	if len(projectUUIDs) == 0 {
+		return nil, fmt.Errorf("Project %w with name %s", ErrNotFound, name)
+	}
+	if len(projectUUIDs) > 1 {
+		return nil, fmt.Errorf("Found %d projects with name %s, use the project UUID instead", len(projectUUIDs), name)
+	}
+
+	return c.GetProject(ctx, projectUUIDs[0])
+}
+
+func (c *Client) CreateProject(ctx context.Context, organisationUUID, name, projectType, dbtVersion string, dbtConnection DbtConnection, warehouseConnection WarehouseConnection) (*Project, error) {
 	createProjectRequest := CreateProjectRequest{
 		OrganisationUUID:    organisationUUID,
 		Name:                name,
@@ -96,7 +182,7 @@ func (c *Client) CreateProject(organisationUUID, name, projectType, dbtVersion s
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/org/projects", c.ApiURL), strings.NewReader(string(newProjectData)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/org/projects", c.ApiURL), strings.NewReader(string(newProjectData)))
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +201,8 @@ func (c *Client) CreateProject(organisationUUID, name, projectType, dbtVersion s
 	return &createProjectResponse.Results.Project, nil
 }
 
-func (c *Client) UpdateProject(projectUUID, name, dbtVersion string, dbtConnection DbtConnection, warehouseConnection WarehouseConnection) (*Project, error) {
+// Returns the UUID of the job compiling the updated project
+func (c *Client) UpdateProject(ctx context.Context, projectUUID, name, dbtVersion string, dbtConnection DbtConnection, warehouseConnection WarehouseConnection) (string, error) {
 
 	projectUpdates := UpdateProjectRequest{
 		Name:                name,
@@ -125,35 +212,51 @@ func (c *Client) UpdateProject(projectUUID, name, dbtVersion string, dbtConnecti
 	}
 	projectUpdateData, err := json.Marshal(projectUpdates)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/projects/%s", c.ApiURL, projectUUID), strings.NewReader(string(projectUpdateData)))
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/projects/%s", c.ApiURL, projectUUID), strings.NewReader(string(projectUpdateData)))
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	body, err, _ := c.doRequest(req)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	updateProjectResponse := UpdateProjectResponse{}
 	err = json.Unmarshal(body, &updateProjectResponse)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	updatedProject, err := c.GetProject(projectUUID)
+	return updateProjectResponse.Results.JobUUID, nil
+}
+
+// Starts compiling the project from its dbt connection, returning the job UUID
+func (c *Client) RefreshProject(ctx context.Context, projectUUID string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/projects/%s/refresh", c.ApiURL, projectUUID), nil)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return updatedProject, nil
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	refreshProjectResponse := UpdateProjectResponse{}
+	err = json.Unmarshal(body, &refreshProjectResponse)
+	if err != nil {
+		return "", err
+	}
+
+	return refreshProjectResponse.Results.JobUUID, nil
 }
 
-func (c *Client) DeleteProject(projectUUID string) (string, error) {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/org/projects/%s", c.ApiURL, projectUUID), nil)
+func (c *Client) DeleteProject(ctx context.Context, projectUUID string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/org/projects/%s", c.ApiURL, projectUUID), nil)
 	if err != nil {
 		return "", err
 	}
@@ -171,3 +274,122 @@ func (c *Client) DeleteProject(projectUUID string) (string, error) {
 
 	return projectResponse.Status, nil
 }
+
+func (c *Client) GetProjectAccess(ctx context.Context, projectUUID string) ([]ProjectMember, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/projects/%s/access", c.ApiURL, projectUUID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	projectAccessResponse := ProjectAccessResponse{}
+	err = json.Unmarshal(body, &projectAccessResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return projectAccessResponse.Results, nil
+}
+
+func (c *Client) GetProjectMember(ctx context.Context, projectUUID, userUUID string) (*ProjectMember, error) {
+	projectMembers, err := c.GetProjectAccess(ctx, projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, projectMember := range projectMembers {
+		if projectMember.UserUUID == userUUID {
+			return &projectMembers[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("Project member %w UUID %s", ErrNotFound, userUUID)
+}
+
+func (c *Client) CreateProjectAccess(ctx context.Context, projectUUID, email, role string) (*ProjectMember, error) {
+	createProjectAccessRequest := CreateProjectAccessRequest{
+		Email:     email,
+		Role:      role,
+		SendEmail: false,
+	}
+	newProjectAccessData, err := json.Marshal(createProjectAccessRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/projects/%s/access", c.ApiURL, projectUUID), strings.NewReader(string(newProjectAccessData)))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err, _ = c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The API does not return the new member, so find it by email
+	projectMembers, err := c.GetProjectAccess(ctx, projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, projectMember := range projectMembers {
+		if strings.EqualFold(projectMember.Email, email) {
+			return &projectMembers[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("Project member %w with email %s", ErrNotFound, email)
+}
+
+func (c *Client) UpdateProjectAccess(ctx context.Context, projectUUID, userUUID, role string) (string, error) {
+	projectAccessUpdates := UpdateProjectAccessRequest{
+		Role: role,
+	}
+	projectAccessUpdateData, err := json.Marshal(projectAccessUpdates)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/projects/%s/access/%s", c.ApiURL, projectUUID, userUUID), strings.NewReader(string(projectAccessUpdateData)))
+	if err != nil {
+		return "", err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	projectAccessStatusResponse := ProjectAccessStatusResponse{}
+	err = json.Unmarshal(body, &projectAccessStatusResponse)
+	if err != nil {
+		return "", err
+	}
+
+	return projectAccessStatusResponse.Status, nil
+}
+
+func (c *Client) DeleteProjectAccess(ctx context.Context, projectUUID, userUUID string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/projects/%s/access/%s", c.ApiURL, projectUUID, userUUID), nil)
+	if err != nil {
+		return "", err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	projectAccessStatusResponse := ProjectAccessStatusResponse{}
+	err = json.Unmarshal(body, &projectAccessStatusResponse)
+	if err != nil {
+		return "", err
+	}
+
+	return projectAccessStatusResponse.Status, nil
+}
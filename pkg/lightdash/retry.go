@@ -0,0 +1,117 @@
+package lightdash
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultMaxRetries     = 3
+	DefaultRetryWaitMin   = 1 * time.Second
+	DefaultRetryWaitMax   = 30 * time.Second
+	DefaultRequestTimeout = 10 * time.Second
+)
+
+// ClientOption configures optional behaviour of the client
+type ClientOption func(*Client)
+
+// WithRetries sets how many times a failed request is retried, and the
+// bounds of the exponential backoff between attempts
+func WithRetries(maxRetries int, retryWaitMin, retryWaitMax time.Duration) ClientOption {
+	return func(c *Client) {
+		c.MaxRetries = maxRetries
+		c.RetryWaitMin = retryWaitMin
+		c.RetryWaitMax = retryWaitMax
+	}
+}
+
+// WithRequestTimeout sets the timeout of a single attempt of a request
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.HTTPClient.Timeout = timeout
+	}
+}
+
+// Methods which can safely be sent again when the outcome of a request is
+// unknown, POST and PATCH are only retried when they were rate limited
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+func shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return idempotentMethods[req.Method]
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if res.StatusCode >= 500 && res.StatusCode != http.StatusNotImplemented {
+		return idempotentMethods[req.Method]
+	}
+	return false
+}
+
+// retryWait returns how long to wait before the given retry, following the
+// Retry-After header when the server sent one, or backing off exponentially
+// from RetryWaitMin with jitter, never waiting longer than RetryWaitMax
+func (c *Client) retryWait(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			if wait > c.RetryWaitMax {
+				return c.RetryWaitMax
+			}
+			return wait
+		}
+	}
+
+	wait := c.RetryWaitMin << attempt
+	if wait > c.RetryWaitMax || wait <= 0 {
+		wait = c.RetryWaitMax
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// Wait at least half of the backoff so that concurrent requests spread out
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// retryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// rewindBody resets the body of the request so that it can be sent again
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
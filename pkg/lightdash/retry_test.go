@@ -0,0 +1,165 @@
+package lightdash
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testRetryServer answers requests to /api/v1/test with the given statuses
+// in turn, and with 200 once they have all been used
+func testRetryServer(t *testing.T, statuses ...int) (*Client, *[]string) {
+	var mu sync.Mutex
+	bodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/test" {
+			w.Write([]byte(`{"status":"ok","results":[]}`))
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) <= len(statuses) {
+			status := statuses[len(bodies)-1]
+			if status == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "0")
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"status":"ok","results":{}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	token := "test-token"
+	c, err := NewClient(context.Background(), &server.URL, nil, nil, &token, WithRetries(2, time.Millisecond, 5*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+	return c, &bodies
+}
+
+func TestDoRequestRetries(t *testing.T) {
+	cases := map[string]struct {
+		method      string
+		statuses    []int
+		attempts    int
+		errorStatus int
+	}{
+		"get retried after server errors":     {"GET", []int{502, 503}, 3, 0},
+		"get gives up after max retries":      {"GET", []int{502, 502, 502}, 3, 502},
+		"post not retried after server error": {"POST", []int{502}, 1, 502},
+		"post retried when rate limited":      {"POST", []int{429, 429}, 3, 0},
+		"patch retried when rate limited":     {"PATCH", []int{429}, 2, 0},
+		"client errors not retried":           {"DELETE", []int{400}, 1, 400},
+		"not implemented not retried":         {"GET", []int{501}, 1, 501},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, bodies := testRetryServer(t, tc.statuses...)
+
+			req, err := http.NewRequest(tc.method, c.ApiURL+"/test", strings.NewReader(`{"name":"test"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err, _ = c.doRequest(req)
+
+			if len(*bodies) != tc.attempts {
+				t.Errorf("expected %d attempts, got %d", tc.attempts, len(*bodies))
+			}
+			for _, body := range *bodies {
+				if body != `{"name":"test"}` {
+					t.Errorf("expected the body to be sent on every attempt, got %q", body)
+				}
+			}
+			var apiErr *APIError
+			if tc.errorStatus == 0 && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if tc.errorStatus != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tc.errorStatus) {
+				t.Errorf("expected an API error with status %d, got %v", tc.errorStatus, err)
+			}
+		})
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	c := &Client{RetryWaitMin: time.Second, RetryWaitMax: 10 * time.Second}
+
+	for attempt, backoff := range []time.Duration{1, 2, 4, 8, 10, 10, 10} {
+		backoff *= time.Second
+		wait := c.retryWait(attempt, nil)
+		if wait < backoff/2 || wait > backoff {
+			t.Errorf("expected the wait before retry %d to be between %s and %s, got %s", attempt, backoff/2, backoff, wait)
+		}
+	}
+
+	// Shifting far enough overflows, which must not turn into no wait
+	if wait := c.retryWait(100, nil); wait < 5*time.Second {
+		t.Errorf("expected the wait to be capped at the maximum, got %s", wait)
+	}
+
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if wait := c.retryWait(0, res); wait != 7*time.Second {
+		t.Errorf("expected Retry-After to be honoured, got %s", wait)
+	}
+
+	res = &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	if wait := c.retryWait(0, res); wait != 10*time.Second {
+		t.Errorf("expected Retry-After to be capped at the maximum, got %s", wait)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if wait, ok := retryAfter("7"); !ok || wait != 7*time.Second {
+		t.Errorf("expected 7s, got %s", wait)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if wait, ok := retryAfter(date); !ok || wait < 50*time.Second || wait > time.Minute {
+		t.Errorf("expected about a minute, got %s", wait)
+	}
+	if _, ok := retryAfter("soon"); ok {
+		t.Error("expected an invalid header to be ignored")
+	}
+	if _, ok := retryAfter(""); ok {
+		t.Error("expected a missing header to be ignored")
+	}
+}
+
+func TestDoRequestCancelledWhileWaiting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/projects/abc" {
+			w.Write([]byte(`{"status":"ok","results":[]}`))
+			return
+		}
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	token := "test-token"
+	c, err := NewClient(context.Background(), &server.URL, nil, nil, &token)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = c.GetProject(ctx, "abc")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to be exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the retry wait to be interrupted, took %s", elapsed)
+	}
+}
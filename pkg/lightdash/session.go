@@ -0,0 +1,84 @@
+package lightdash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// usesSession reports whether the client logs in with a username and
+// password, rather than sending a personal access token
+func (c *Client) usesSession() bool {
+	return c.Token == "" && c.Username != ""
+}
+
+func (c *Client) currentSession() int {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	return c.session
+}
+
+// relogin logs in again after a request made with the given session was
+// rejected. Requests running in parallel are all rejected when the session
+// expires, only the first of them logs in and the others reuse its session.
+func (c *Client) relogin(ctx context.Context, session int) error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.session != session {
+		return nil
+	}
+	if err := c.login(ctx); err != nil {
+		return err
+	}
+	c.session++
+	return nil
+}
+
+// login starts a new session, which the cookie jar then sends with every
+// request
+func (c *Client) login(ctx context.Context) error {
+	loginRequest := LoginRequest{
+		Email:    c.Username,
+		Password: c.Password,
+	}
+	loginRequestData, err := json.Marshal(loginRequest)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/login", c.ApiURL), strings.NewReader(string(loginRequestData)))
+	if err != nil {
+		return err
+	}
+	c.setHeaders(req)
+
+	body, err, _ := c.send(req)
+	if err != nil {
+		return fmt.Errorf("Unable to login as %s: %w", c.Username, err)
+	}
+
+	loginResponse := LoginResponse{}
+	err = json.Unmarshal(body, &loginResponse)
+	if err != nil {
+		return fmt.Errorf("Unable to login as %s: %w", c.Username, err)
+	}
+	if loginResponse.Status != "ok" {
+		return fmt.Errorf("Unable to login as %s: unexpected status %q", c.Username, loginResponse.Status)
+	}
+
+	return nil
+}
+
+// isUnauthorized reports whether the request was rejected for not being
+// logged in. A 403 is a permission denial, logging in again would not help.
+func isUnauthorized(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusUnauthorized
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+package lightdash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testSessionServer issues a new session cookie on every login, and only
+// accepts the latest one
+type testSessionServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	password string
+	logins   int
+	session  string
+}
+
+func newTestSessionServer(t *testing.T) *testSessionServer {
+	s := &testSessionServer{password: "correct-password"}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testSessionServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/api/v1/login" {
+		loginRequest := LoginRequest{}
+		json.NewDecoder(r.Body).Decode(&loginRequest)
+		if loginRequest.Password != s.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"status":"error","error":{"statusCode":401,"name":"AuthorizationError","message":"Email and password not recognized"}}`))
+			return
+		}
+		s.logins++
+		s.session = fmt.Sprintf("session-%d", s.logins)
+		http.SetCookie(w, &http.Cookie{Name: "connect.sid", Value: s.session, Path: "/"})
+		w.Write([]byte(`{"status":"ok","results":{}}`))
+		return
+	}
+
+	cookie, err := r.Cookie("connect.sid")
+	if err != nil || cookie.Value != s.session {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"status":"error","error":{"statusCode":401,"name":"AuthorizationError","message":"User session not found"}}`))
+		return
+	}
+	w.Write([]byte(`{"status":"ok","results":{"organizationUuid":"org-1","name":"Org"}}`))
+}
+
+// expire invalidates the current session, as if it timed out
+func (s *testSessionServer) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.session = ""
+}
+
+func (s *testSessionServer) loginCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.logins
+}
+
+func (s *testSessionServer) client(t *testing.T) *Client {
+	username := "user@example.com"
+	password := "correct-password"
+	c, err := NewClient(context.Background(), &s.URL, &username, &password, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+	return c
+}
+
+func TestSessionLoginFailure(t *testing.T) {
+	server := newTestSessionServer(t)
+
+	username := "user@example.com"
+	password := "wrong-password"
+	_, err := NewClient(context.Background(), &server.URL, &username, &password, nil)
+	if err == nil || !strings.Contains(err.Error(), "Unable to login as user@example.com") || !strings.Contains(err.Error(), "Email and password not recognized") {
+		t.Fatalf("expected a login error, got %v", err)
+	}
+}
+
+func TestSessionReloginWhenExpired(t *testing.T) {
+	server := newTestSessionServer(t)
+	c := server.client(t)
+
+	if _, err := c.GetOrganization(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	server.expire()
+	organization, err := c.GetOrganization(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error after the session expired: %s", err)
+	}
+	if organization.UUID != "org-1" {
+		t.Errorf("expected the organization to be read, got %v", organization)
+	}
+	if logins := server.loginCount(); logins != 2 {
+		t.Errorf("expected to login again once, got %d logins", logins)
+	}
+}
+
+func TestSessionReloginOnlyOnce(t *testing.T) {
+	server := newTestSessionServer(t)
+	c := server.client(t)
+
+	// Logging in works, but the session is rejected straight away
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.expire()
+		server.handle(w, r)
+	})
+
+	_, err := c.GetOrganization(context.Background())
+	if !isUnauthorized(err) {
+		t.Fatalf("expected the request to be rejected, got %v", err)
+	}
+	if logins := server.loginCount(); logins != 2 {
+		t.Errorf("expected to login again once, got %d logins", logins)
+	}
+}
+
+func TestSessionReloginInParallel(t *testing.T) {
+	server := newTestSessionServer(t)
+	c := server.client(t)
+
+	server.expire()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.GetOrganization(context.Background())
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+	if logins := server.loginCount(); logins != 2 {
+		t.Errorf("expected a single login for all the parallel requests, got %d logins", logins)
+	}
+}
+
+func TestSessionNotReloggedInWhenForbidden(t *testing.T) {
+	server := newTestSessionServer(t)
+	c := server.client(t)
+
+	deletes := 0
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			deletes++
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"status":"error","error":{"statusCode":403,"name":"ForbiddenError","message":"You don't have access to this space"}}`))
+			return
+		}
+		server.handle(w, r)
+	})
+
+	_, err := c.DeleteSpace(context.Background(), "project-1", "space-1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected the permission denial to be returned, got %v", err)
+	}
+	if logins := server.loginCount(); logins != 1 {
+		t.Errorf("expected no login after a permission denial, got %d logins", logins)
+	}
+	if deletes != 1 {
+		t.Errorf("expected the request to be sent once, got %d", deletes)
+	}
+}
+
+func TestTokenNotReauthenticated(t *testing.T) {
+	server := newTestSessionServer(t)
+	token := "test-token"
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/org/projects" {
+			w.Write([]byte(`{"status":"ok","results":[]}`))
+			return
+		}
+		server.handle(w, r)
+	})
+	c, err := NewClient(context.Background(), &server.URL, nil, nil, &token)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	if _, err := c.GetOrganization(context.Background()); !isUnauthorized(err) {
+		t.Fatalf("expected the request to be rejected, got %v", err)
+	}
+	if logins := server.loginCount(); logins != 0 {
+		t.Errorf("expected no login with a token, got %d logins", logins)
+	}
+}
@@ -0,0 +1,227 @@
+package lightdash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type SpaceAccess struct {
+	UserUUID        string `json:"userUuid"`
+	Email           string `json:"email,omitempty"`
+	Role            string `json:"role"`
+	HasDirectAccess bool   `json:"hasDirectAccess"`
+}
+
+type SpaceGroupAccess struct {
+	GroupUUID string `json:"groupUuid"`
+	GroupName string `json:"groupName,omitempty"`
+	SpaceRole string `json:"spaceRole"`
+}
+
+type Space struct {
+	SpaceUUID        string             `json:"uuid,omitempty"`
+	Name             string             `json:"name,omitempty"`
+	ProjectUUID      string             `json:"projectUuid,omitempty"`
+	OrganizationUUID string             `json:"organizationUuid,omitempty"`
+	IsPrivate        bool               `json:"isPrivate"`
+	ParentSpaceUUID  string             `json:"parentSpaceUuid,omitempty"`
+	Access           []SpaceAccess      `json:"access,omitempty"`
+	GroupsAccess     []SpaceGroupAccess `json:"groupsAccess,omitempty"`
+}
+
+type CreateSpaceRequest struct {
+	Name            string `json:"name"`
+	IsPrivate       bool   `json:"isPrivate"`
+	ParentSpaceUUID string `json:"parentSpaceUuid,omitempty"`
+}
+
+type UpdateSpaceRequest struct {
+	Name      string `json:"name"`
+	IsPrivate bool   `json:"isPrivate"`
+}
+
+type ShareSpaceRequest struct {
+	UserUUID  string `json:"userUuid,omitempty"`
+	GroupUUID string `json:"groupUuid,omitempty"`
+	SpaceRole string `json:"spaceRole"`
+}
+
+type ShareSpaceResponse struct {
+	Status string `json:"status"`
+}
+
+type SpaceResponse struct {
+	Results Space  `json:"results"`
+	Status  string `json:"status"`
+}
+
+func (c *Client) GetSpace(ctx context.Context, projectUUID, spaceUUID string) (*Space, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/projects/%s/spaces/%s", c.ApiURL, projectUUID, spaceUUID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	spaceResponse := SpaceResponse{}
+	err = json.Unmarshal(body, &spaceResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &spaceResponse.Results, nil
+}
+
+func (c *Client) CreateSpace(ctx context.Context, projectUUID, name string, isPrivate bool, parentSpaceUUID string) (*Space, error) {
+	createSpaceRequest := CreateSpaceRequest{
+		Name:            name,
+		IsPrivate:       isPrivate,
+		ParentSpaceUUID: parentSpaceUUID,
+	}
+	newSpaceData, err := json.Marshal(createSpaceRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/projects/%s/spaces", c.ApiURL, projectUUID), strings.NewReader(string(newSpaceData)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	spaceResponse := SpaceResponse{}
+	err = json.Unmarshal(body, &spaceResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &spaceResponse.Results, nil
+}
+
+func (c *Client) UpdateSpace(ctx context.Context, projectUUID, spaceUUID, name string, isPrivate bool) (*Space, error) {
+	spaceUpdates := UpdateSpaceRequest{
+		Name:      name,
+		IsPrivate: isPrivate,
+	}
+	spaceUpdateData, err := json.Marshal(spaceUpdates)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/projects/%s/spaces/%s", c.ApiURL, projectUUID, spaceUUID), strings.NewReader(string(spaceUpdateData)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	spaceResponse := SpaceResponse{}
+	err = json.Unmarshal(body, &spaceResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &spaceResponse.Results, nil
+}
+
+func (c *Client) DeleteSpace(ctx context.Context, projectUUID, spaceUUID string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/projects/%s/spaces/%s", c.ApiURL, projectUUID, spaceUUID), nil)
+	if err != nil {
+		return "", err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	spaceResponse := SpaceResponse{}
+	err = json.Unmarshal(body, &spaceResponse)
+	if err != nil {
+		return "", err
+	}
+
+	return spaceResponse.Status, nil
+}
+
+func (c *Client) shareSpace(ctx context.Context, url string, shareSpaceRequest ShareSpaceRequest) (string, error) {
+	shareSpaceData, err := json.Marshal(shareSpaceRequest)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(string(shareSpaceData)))
+	if err != nil {
+		return "", err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	shareSpaceResponse := ShareSpaceResponse{}
+	err = json.Unmarshal(body, &shareSpaceResponse)
+	if err != nil {
+		return "", err
+	}
+
+	return shareSpaceResponse.Status, nil
+}
+
+func (c *Client) unshareSpace(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	shareSpaceResponse := ShareSpaceResponse{}
+	err = json.Unmarshal(body, &shareSpaceResponse)
+	if err != nil {
+		return "", err
+	}
+
+	return shareSpaceResponse.Status, nil
+}
+
+func (c *Client) ShareSpaceWithUser(ctx context.Context, projectUUID, spaceUUID, userUUID, spaceRole string) (string, error) {
+	return c.shareSpace(
+		ctx,
+		fmt.Sprintf("%s/projects/%s/spaces/%s/share", c.ApiURL, projectUUID, spaceUUID),
+		ShareSpaceRequest{UserUUID: userUUID, SpaceRole: spaceRole},
+	)
+}
+
+func (c *Client) UnshareSpaceWithUser(ctx context.Context, projectUUID, spaceUUID, userUUID string) (string, error) {
+	return c.unshareSpace(ctx, fmt.Sprintf("%s/projects/%s/spaces/%s/share/%s", c.ApiURL, projectUUID, spaceUUID, userUUID))
+}
+
+func (c *Client) ShareSpaceWithGroup(ctx context.Context, projectUUID, spaceUUID, groupUUID, spaceRole string) (string, error) {
+	return c.shareSpace(
+		ctx,
+		fmt.Sprintf("%s/projects/%s/spaces/%s/group/share", c.ApiURL, projectUUID, spaceUUID),
+		ShareSpaceRequest{GroupUUID: groupUUID, SpaceRole: spaceRole},
+	)
+}
+
+func (c *Client) UnshareSpaceWithGroup(ctx context.Context, projectUUID, spaceUUID, groupUUID string) (string, error) {
+	return c.unshareSpace(ctx, fmt.Sprintf("%s/projects/%s/spaces/%s/group/share/%s", c.ApiURL, projectUUID, spaceUUID, groupUUID))
+}
@@ -0,0 +1,79 @@
+package lightdash
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// NewTLSConfig builds the TLS configuration used to reach a Lightdash
+// instance served with a certificate from a private CA, or which asks for a
+// client certificate. caPEM is trusted on top of the system certificates.
+func NewTLSConfig(caPEM []byte, insecureSkipVerify bool, clientCertPEM, clientKeyPEM []byte) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+
+	if len(caPEM) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("No valid PEM certificates found in the CA certificates")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if len(clientCertPEM) > 0 || len(clientKeyPEM) > 0 {
+		certificate, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// WithTLSConfig sets the TLS configuration used for all requests
+func WithTLSConfig(tlsConfig *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.transport().TLSClientConfig = tlsConfig
+	}
+}
+
+// WithProxy sends all requests through the given proxy rather than the one
+// set in the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+// Credentials in the URL are sent to the proxy.
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(c *Client) {
+		c.transport().Proxy = http.ProxyURL(proxyURL)
+	}
+}
+
+// WithExtraHeaders adds headers to every request, e.g. for a reverse proxy
+// which authenticates requests before they reach Lightdash. The headers are
+// masked in logs.
+func WithExtraHeaders(headers map[string]string) ClientOption {
+	return func(c *Client) {
+		c.extraHeaders = http.Header{}
+		for name, value := range headers {
+			c.extraHeaders.Set(name, value)
+		}
+	}
+}
+
+// transport returns the transport of the HTTP client, starting from a copy
+// of the default transport the first time it is customised
+func (c *Client) transport() *http.Transport {
+	if transport, ok := c.HTTPClient.Transport.(*http.Transport); ok {
+		return transport
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	c.HTTPClient.Transport = transport
+	return transport
+}
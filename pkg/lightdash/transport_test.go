@@ -0,0 +1,191 @@
+package lightdash
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func testProjectsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(`{"status":"ok","results":[]}`))
+}
+
+func testClient(serverURL string, options ...ClientOption) (*Client, error) {
+	token := "test-token"
+	return NewClient(context.Background(), &serverURL, nil, nil, &token, options...)
+}
+
+func certificatePEM(certificate *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})
+}
+
+// newTestClientCertificate generates a self-signed client certificate, and
+// returns it with its key as PEM
+func newTestClientCertificate(t *testing.T) (*x509.Certificate, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %s", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %s", err)
+	}
+	return certificate, certificatePEM(certificate), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(testProjectsHandler))
+	t.Cleanup(server.Close)
+
+	cases := map[string]struct {
+		caPEM              []byte
+		insecureSkipVerify bool
+		expectError        bool
+	}{
+		"unknown CA":      {nil, false, true},
+		"CA certificate":  {certificatePEM(server.Certificate()), false, false},
+		"skip verifying":  {nil, true, false},
+		"CA and skipping": {certificatePEM(server.Certificate()), true, false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tlsConfig, err := NewTLSConfig(tc.caPEM, tc.insecureSkipVerify, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error building the TLS configuration: %s", err)
+			}
+			_, err = testClient(server.URL, WithTLSConfig(tlsConfig), WithRetries(0, 0, 0))
+			if tc.expectError && err == nil {
+				t.Errorf("expected the certificate to be rejected")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestTLSConfigInvalid(t *testing.T) {
+	if _, err := NewTLSConfig([]byte("not a certificate"), false, nil, nil); err == nil || !strings.Contains(err.Error(), "No valid PEM certificates") {
+		t.Errorf("expected an invalid CA error, got %v", err)
+	}
+	if _, err := NewTLSConfig(nil, false, []byte("not a certificate"), []byte("not a key")); err == nil || !strings.Contains(err.Error(), "Invalid client certificate") {
+		t.Errorf("expected an invalid client certificate error, got %v", err)
+	}
+}
+
+func TestClientCertificate(t *testing.T) {
+	clientCertificate, clientCertPEM, clientKeyPEM := newTestClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCertificate)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(testProjectsHandler))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	caPEM := certificatePEM(server.Certificate())
+
+	tlsConfig, err := NewTLSConfig(caPEM, false, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error building the TLS configuration: %s", err)
+	}
+	if _, err := testClient(server.URL, WithTLSConfig(tlsConfig), WithRetries(0, 0, 0)); err == nil {
+		t.Errorf("expected the server to ask for a client certificate")
+	}
+
+	tlsConfig, err = NewTLSConfig(caPEM, false, clientCertPEM, clientKeyPEM)
+	if err != nil {
+		t.Fatalf("unexpected error building the TLS configuration: %s", err)
+	}
+	if _, err := testClient(server.URL, WithTLSConfig(tlsConfig), WithRetries(0, 0, 0)); err != nil {
+		t.Errorf("unexpected error with a client certificate: %s", err)
+	}
+}
+
+func TestProxy(t *testing.T) {
+	var proxied []string
+	var proxyAuthorization string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		proxyAuthorization = r.Header.Get("Proxy-Authorization")
+		testProjectsHandler(w, r)
+	}))
+	t.Cleanup(proxy.Close)
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	proxyURL.User = url.UserPassword("proxy-user", "proxy-password")
+	if _, err := testClient("http://lightdash.example.com", WithProxy(proxyURL)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(proxied) != 1 || proxied[0] != "http://lightdash.example.com/api/v1/org/projects" {
+		t.Errorf("expected the request to go through the proxy, got %v", proxied)
+	}
+	if !strings.HasPrefix(proxyAuthorization, "Basic ") {
+		t.Errorf("expected the proxy credentials to be sent, got %q", proxyAuthorization)
+	}
+}
+
+func TestExtraHeaders(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		testProjectsHandler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := testClient(server.URL, WithExtraHeaders(map[string]string{
+		"x-gateway-token": "gateway-secret",
+		"Authorization":   "Bearer overridden",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	if _, err := c.GetProjects(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if value := received.Get("X-Gateway-Token"); value != "gateway-secret" {
+		t.Errorf("expected the extra header to be sent, got %q", value)
+	}
+	if value := received.Get("Authorization"); value != "ApiKey test-token" {
+		t.Errorf("expected the token to take precedence over the extra header, got %q", value)
+	}
+	if logs := output.String(); strings.Contains(logs, "gateway-secret") || !strings.Contains(logs, "X-Gateway-Token") {
+		t.Errorf("expected the extra header to be masked in logs, got %s", logs)
+	}
+}
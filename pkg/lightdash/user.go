@@ -1,12 +1,27 @@
 package lightdash
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
+// Roles a user can hold within an organization, from least to most privileged
+var OrganizationRoles = []string{
+	"member",
+	"viewer",
+	"interactive_viewer",
+	"editor",
+	"developer",
+	"admin",
+}
+
+// Upper bound of members returned when reading a group, the API only
+// includes members when asked to and truncates to the given number
+const maxGroupMembers = 10000
+
 type User struct {
 	UserUUID         string  `json:"userUuid,omitempty"`
 	FirstName        string  `json:"firstName,omitempty"`
@@ -30,8 +45,42 @@ type UsersResponse struct {
 	Status  string `json:"status"`
 }
 
-func (c *Client) GetUser(userUUID string) (*User, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/org/users", c.ApiURL), nil)
+type GroupMember struct {
+	UserUUID  string `json:"userUuid"`
+	Email     string `json:"email,omitempty"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+}
+
+type Group struct {
+	GroupUUID        string        `json:"uuid,omitempty"`
+	Name             string        `json:"name,omitempty"`
+	OrganizationUUID string        `json:"organizationUuid,omitempty"`
+	Members          []GroupMember `json:"members,omitempty"`
+}
+
+type GroupMemberRequest struct {
+	UserUUID string `json:"userUuid"`
+}
+
+type CreateGroupRequest struct {
+	Name    string               `json:"name"`
+	Members []GroupMemberRequest `json:"members,omitempty"`
+}
+
+type UpdateGroupRequest struct {
+	Name    string                `json:"name,omitempty"`
+	Members *[]GroupMemberRequest `json:"members,omitempty"`
+}
+
+type GroupResponse struct {
+	Results Group  `json:"results"`
+	Status  string `json:"status"`
+}
+
+// GetUsers lists every user of the organization
+func (c *Client) GetUsers(ctx context.Context) ([]User, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/org/users", c.ApiURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -47,16 +96,41 @@ func (c *Client) GetUser(userUUID string) (*User, error) {
 		return nil, err
 	}
 
-	for i, user := range usersResponse.Results {
+	return usersResponse.Results, nil
+}
+
+func (c *Client) GetUser(ctx context.Context, userUUID string) (*User, error) {
+	users, err := c.GetUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, user := range users {
 		if user.UserUUID == userUUID {
-			return &usersResponse.Results[i], nil
+			return &users[i], nil
 		}
 	}
 
-	return nil, fmt.Errorf("User not found UUID %s", userUUID)
+	return nil, fmt.Errorf("User %w UUID %s", ErrNotFound, userUUID)
 }
 
-func (c *Client) UpdateUser(userID string, role string) (*User, error) {
+// GetUserByEmail finds a user of the organization, emails are compared case-insensitively
+func (c *Client) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	users, err := c.GetUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, user := range users {
+		if strings.EqualFold(user.Email, email) {
+			return &users[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("User %w with email %s", ErrNotFound, email)
+}
+
+func (c *Client) UpdateUser(ctx context.Context, userID string, role string) (*User, error) {
 	updatedUser := User{
 		Role: role,
 	}
@@ -65,7 +139,7 @@ func (c *Client) UpdateUser(userID string, role string) (*User, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/org/users/%s", c.ApiURL, userID), strings.NewReader(string(updatedUserData)))
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/org/users/%s", c.ApiURL, userID), strings.NewReader(string(updatedUserData)))
 	if err != nil {
 		return nil, err
 	}
@@ -84,8 +158,8 @@ func (c *Client) UpdateUser(userID string, role string) (*User, error) {
 	return &userResponse.Results, nil
 }
 
-func (c *Client) DeleteUser(userID string) (string, error) {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/org/user/%s", c.ApiURL, userID), nil)
+func (c *Client) DeleteUser(ctx context.Context, userID string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/org/user/%s", c.ApiURL, userID), nil)
 	if err != nil {
 		return "", err
 	}
@@ -103,3 +177,153 @@ func (c *Client) DeleteUser(userID string) (string, error) {
 
 	return userResponse.Status, nil
 }
+
+func groupMemberRequests(memberUUIDs []string) []GroupMemberRequest {
+	members := []GroupMemberRequest{}
+	for _, memberUUID := range memberUUIDs {
+		members = append(members, GroupMemberRequest{UserUUID: memberUUID})
+	}
+	return members
+}
+
+func (c *Client) GetGroup(ctx context.Context, groupUUID string) (*Group, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/groups/%s?includeMembers=%d", c.ApiURL, groupUUID, maxGroupMembers), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	groupResponse := GroupResponse{}
+	err = json.Unmarshal(body, &groupResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &groupResponse.Results, nil
+}
+
+func (c *Client) CreateGroup(ctx context.Context, name string, memberUUIDs []string) (*Group, error) {
+	createGroupRequest := CreateGroupRequest{
+		Name:    name,
+		Members: groupMemberRequests(memberUUIDs),
+	}
+	newGroupData, err := json.Marshal(createGroupRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/org/groups", c.ApiURL), strings.NewReader(string(newGroupData)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	groupResponse := GroupResponse{}
+	err = json.Unmarshal(body, &groupResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &groupResponse.Results, nil
+}
+
+// UpdateGroup renames the group, and replaces its members when memberUUIDs is not nil
+func (c *Client) UpdateGroup(ctx context.Context, groupUUID, name string, memberUUIDs []string) (*Group, error) {
+	groupUpdates := UpdateGroupRequest{
+		Name: name,
+	}
+	if memberUUIDs != nil {
+		members := groupMemberRequests(memberUUIDs)
+		groupUpdates.Members = &members
+	}
+	groupUpdateData, err := json.Marshal(groupUpdates)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/groups/%s", c.ApiURL, groupUUID), strings.NewReader(string(groupUpdateData)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	groupResponse := GroupResponse{}
+	err = json.Unmarshal(body, &groupResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &groupResponse.Results, nil
+}
+
+func (c *Client) DeleteGroup(ctx context.Context, groupUUID string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/groups/%s", c.ApiURL, groupUUID), nil)
+	if err != nil {
+		return "", err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	groupResponse := GroupResponse{}
+	err = json.Unmarshal(body, &groupResponse)
+	if err != nil {
+		return "", err
+	}
+
+	return groupResponse.Status, nil
+}
+
+func (c *Client) AddGroupMember(ctx context.Context, groupUUID, userUUID string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/groups/%s/members/%s", c.ApiURL, groupUUID, userUUID), nil)
+	if err != nil {
+		return "", err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	groupResponse := GroupResponse{}
+	err = json.Unmarshal(body, &groupResponse)
+	if err != nil {
+		return "", err
+	}
+
+	return groupResponse.Status, nil
+}
+
+func (c *Client) RemoveGroupMember(ctx context.Context, groupUUID, userUUID string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/groups/%s/members/%s", c.ApiURL, groupUUID, userUUID), nil)
+	if err != nil {
+		return "", err
+	}
+
+	body, err, _ := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	groupResponse := GroupResponse{}
+	err = json.Unmarshal(body, &groupResponse)
+	if err != nil {
+		return "", err
+	}
+
+	return groupResponse.Status, nil
+}
@@ -0,0 +1,128 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var groupSchema = map[string]*schema.Schema{
+	"name": &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Group name",
+	},
+	"members": &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "UUIDs of the users in the group, when set membership is managed authoritatively and should not be combined with `lightdash_group_member`. Emptying or removing the set removes all the users from the group",
+	},
+}
+
+func ResourceGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupCreate,
+		ReadContext:   resourceGroupRead,
+		UpdateContext: resourceGroupUpdate,
+		DeleteContext: resourceGroupDelete,
+
+		Schema: groupSchema,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	groupID := d.Id()
+
+	group, err := c.GetGroup(ctx, groupID)
+	if lightdash.IsNotFound(err) {
+		// The group has been deleted outside of Terraform
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	members := []string{}
+	for _, member := range group.Members {
+		members = append(members, member.UserUUID)
+	}
+
+	if err := d.Set("name", group.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	// Members are only read back while they are managed here, so that users
+	// added with lightdash_group_member don't show up as changes
+	if d.Get("members").(*schema.Set).Len() > 0 {
+		if err := d.Set("members", members); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	name := d.Get("name").(string)
+	members := []string{}
+	for _, member := range d.Get("members").(*schema.Set).List() {
+		members = append(members, member.(string))
+	}
+
+	group, err := c.CreateGroup(ctx, name, members)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(group.GroupUUID)
+
+	return resourceGroupRead(ctx, d, m)
+}
+
+func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+	groupID := d.Id()
+
+	if d.HasChange("name") || d.HasChange("members") {
+		var members []string
+		if d.HasChange("members") {
+			members = []string{}
+			for _, member := range d.Get("members").(*schema.Set).List() {
+				members = append(members, member.(string))
+			}
+		}
+
+		_, err := c.UpdateGroup(ctx, groupID, d.Get("name").(string), members)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceGroupRead(ctx, d, m)
+}
+
+func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+	groupID := d.Id()
+
+	var diags diag.Diagnostics
+
+	status, err := c.DeleteGroup(ctx, groupID)
+	if (status != "ok") || (err != nil) {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
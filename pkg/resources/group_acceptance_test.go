@@ -0,0 +1,160 @@
+package resources_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLightdashGroupResource(t *testing.T) {
+
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	name2 := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	email := "gthesheep@gmail.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLightdashGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLightdashGroupResourceBasicConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashGroupExists("lightdash_group.test_group"),
+					resource.TestCheckResourceAttr("lightdash_group.test_group", "name", name),
+					resource.TestCheckResourceAttr("lightdash_group.test_group", "members.#", "0"),
+				),
+			},
+			// MODIFY
+			{
+				Config: testAccLightdashGroupResourceFullConfig(name2, email),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashGroupExists("lightdash_group.test_group"),
+					resource.TestCheckResourceAttr("lightdash_group.test_group", "name", name2),
+					resource.TestCheckResourceAttr("lightdash_group.test_group", "members.#", "1"),
+				),
+			},
+			// IMPORT
+			{
+				ResourceName:            "lightdash_group.test_group",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{},
+			},
+		},
+	})
+}
+
+func TestAccLightdashGroupMemberResource(t *testing.T) {
+
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	email := "gthesheep@gmail.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLightdashGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLightdashGroupMemberResourceConfig(name, email),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashGroupExists("lightdash_group.test_group"),
+					resource.TestCheckResourceAttrPair("lightdash_group_member.test_group_member", "group_uuid", "lightdash_group.test_group", "id"),
+					resource.TestCheckResourceAttrPair("lightdash_group_member.test_group_member", "user_uuid", "lightdash_user.test_user", "id"),
+				),
+			},
+			// IMPORT
+			{
+				ResourceName:            "lightdash_group_member.test_group_member",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{},
+			},
+		},
+	})
+}
+
+func testAccLightdashGroupResourceBasicConfig(name string) string {
+	return fmt.Sprintf(`
+resource "lightdash_group" "test_group" {
+    name = "%s"
+    members = []
+}
+`, name)
+}
+
+func testAccLightdashGroupResourceFullConfig(name, email string) string {
+	return fmt.Sprintf(`
+resource "lightdash_user" "test_user" {
+    email = "%s"
+}
+
+resource "lightdash_group" "test_group" {
+    name = "%s"
+    members = [lightdash_user.test_user.id]
+}
+`, email, name)
+}
+
+func testAccLightdashGroupMemberResourceConfig(name, email string) string {
+	return fmt.Sprintf(`
+resource "lightdash_user" "test_user" {
+    email = "%s"
+}
+
+resource "lightdash_group" "test_group" {
+    name = "%s"
+}
+
+resource "lightdash_group_member" "test_group_member" {
+    group_uuid = lightdash_group.test_group.id
+    user_uuid = lightdash_user.test_user.id
+}
+`, email, name)
+}
+
+func testAccCheckLightdashGroupExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+		apiClient := testAccProvider.Meta().(*lightdash.Client)
+		_, err := apiClient.GetGroup(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
+		}
+		return nil
+	}
+}
+
+func testAccCheckLightdashGroupDestroy(s *terraform.State) error {
+	apiClient := testAccProvider.Meta().(*lightdash.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "lightdash_group" {
+			continue
+		}
+
+		_, err := apiClient.GetGroup(context.Background(), rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Group still exists")
+		}
+		notFoundErr := "not found"
+		expectedErr := regexp.MustCompile(notFoundErr)
+		if !expectedErr.Match([]byte(err.Error())) {
+			return fmt.Errorf("expected %s, got %s", notFoundErr, err)
+		}
+	}
+
+	return nil
+}
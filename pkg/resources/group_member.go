@@ -0,0 +1,123 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var groupMemberSchema = map[string]*schema.Schema{
+	"group_uuid": &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "UUID of the group to add the user to",
+	},
+	"user_uuid": &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "UUID of the user to add to the group",
+	},
+}
+
+func ResourceGroupMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupMemberCreate,
+		ReadContext:   resourceGroupMemberRead,
+		DeleteContext: resourceGroupMemberDelete,
+
+		Schema: groupMemberSchema,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func splitGroupMemberID(id string) (string, string, error) {
+	parts := strings.Split(id, lightdash.ID_DELIMITER)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Unexpected format of ID (%s), expected <group_uuid>%s<user_uuid>", id, lightdash.ID_DELIMITER)
+	}
+	return parts[0], parts[1], nil
+}
+
+func resourceGroupMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	groupUUID, userUUID, err := splitGroupMemberID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	group, err := c.GetGroup(ctx, groupUUID)
+	if lightdash.IsNotFound(err) {
+		// The group has been deleted outside of Terraform
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	isMember := false
+	for _, member := range group.Members {
+		if member.UserUUID == userUUID {
+			isMember = true
+		}
+	}
+	if !isMember {
+		// The user has been removed from the group outside of Terraform
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("group_uuid", groupUUID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("user_uuid", userUUID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceGroupMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	groupUUID := d.Get("group_uuid").(string)
+	userUUID := d.Get("user_uuid").(string)
+
+	_, err := c.AddGroupMember(ctx, groupUUID, userUUID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s%s%s", groupUUID, lightdash.ID_DELIMITER, userUUID))
+
+	return resourceGroupMemberRead(ctx, d, m)
+}
+
+func resourceGroupMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	groupUUID, userUUID, err := splitGroupMemberID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	status, err := c.RemoveGroupMember(ctx, groupUUID, userUUID)
+	if (status != "ok") || (err != nil) {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
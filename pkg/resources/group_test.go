@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// handleGroup serves /org/groups and /groups/{groupUuid}
+func (s *testLightdashServer) handleGroup(w http.ResponseWriter, method string, parts []string, body []byte) {
+	if method == "POST" && parts[1] == "org" {
+		group := map[string]interface{}{}
+		if err := json.Unmarshal(body, &group); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		group["uuid"] = fmt.Sprintf("group-%d", len(s.groups)+1)
+		if _, ok := group["members"]; !ok {
+			group["members"] = []interface{}{}
+		}
+		s.groups[group["uuid"].(string)] = group
+		s.respond(w, group)
+		return
+	}
+
+	group, ok := s.groups[parts[2]]
+	if !ok {
+		http.Error(w, `{"status":"error","error":{"statusCode":404,"name":"NotFoundError","message":"Group not found"}}`, http.StatusNotFound)
+		return
+	}
+	switch method {
+	case "GET":
+		s.respond(w, group)
+	case "PATCH":
+		update := map[string]interface{}{}
+		if err := json.Unmarshal(body, &update); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for key, value := range update {
+			group[key] = value
+		}
+		s.respond(w, group)
+	case "DELETE":
+		delete(s.groups, parts[2])
+		s.respond(w, nil)
+	default:
+		http.Error(w, "unexpected request", http.StatusNotImplemented)
+	}
+}
+
+func (s *testLightdashServer) groupMembers(groupUUID string) []interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.groups[groupUUID]["members"].([]interface{})
+}
+
+func TestResourceGroupMembers(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"emptied": {
+			"name":    "Analysts",
+			"members": []interface{}{},
+		},
+		"removed": {
+			"name": "Analysts",
+		},
+	}
+
+	for name, clearedConfig := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := newTestLightdashServer(t)
+			client := server.client(t)
+			r := ResourceGroup()
+
+			config := map[string]interface{}{
+				"name":    "Analysts",
+				"members": []interface{}{"user-1", "user-2"},
+			}
+			state := testResourceApply(t, r, nil, config, client)
+			testResourcePlanIsEmpty(t, r, state, config, client)
+			if members := server.groupMembers(state.ID); len(members) != 2 {
+				t.Fatalf("expected 2 members, got %v", members)
+			}
+
+			state = testResourceApply(t, r, state, clearedConfig, client)
+			testResourcePlanIsEmpty(t, r, state, clearedConfig, client)
+			if members := server.groupMembers(state.ID); len(members) != 0 {
+				t.Errorf("expected the members to be removed, got %v", members)
+			}
+		})
+	}
+}
+
+func TestResourceGroupWithoutMembers(t *testing.T) {
+	server := newTestLightdashServer(t)
+	client := server.client(t)
+	r := ResourceGroup()
+
+	config := map[string]interface{}{
+		"name": "Analysts",
+	}
+	state := testResourceApply(t, r, nil, config, client)
+
+	// Users added with lightdash_group_member are not changes to the group
+	server.mu.Lock()
+	server.groups[state.ID]["members"] = []interface{}{
+		map[string]interface{}{"userUuid": "user-1"},
+	}
+	server.mu.Unlock()
+	testResourcePlanIsEmpty(t, r, state, config, client)
+}
@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Polls the job until it has finished, returning an error diagnostic for
+// each failed step
+func waitForProjectJob(ctx context.Context, c *lightdash.Client, jobUUID string, timeout time.Duration) (*lightdash.Job, diag.Diagnostics) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{lightdash.JobStatusStarted, lightdash.JobStatusRunning},
+		Target:  []string{lightdash.JobStatusDone, lightdash.JobStatusError},
+		Refresh: func() (interface{}, string, error) {
+			job, err := c.GetJob(ctx, jobUUID)
+			if err != nil {
+				return nil, "", err
+			}
+			return job, job.JobStatus, nil
+		},
+		Timeout: timeout,
+	}
+
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, diag.FromErr(fmt.Errorf("Error waiting for job (%s) to finish: %w", jobUUID, err))
+	}
+	job := result.(*lightdash.Job)
+
+	return job, jobDiagnostics(job)
+}
+
+func jobDiagnostics(job *lightdash.Job) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if job.JobStatus != lightdash.JobStatusError {
+		return diags
+	}
+
+	for _, step := range job.Steps {
+		if step.StepStatus != lightdash.JobStatusError {
+			continue
+		}
+		// The dbt logs hold the compilation errors, the step error is
+		// usually just a summary of them
+		details := []string{}
+		if step.StepError != "" {
+			details = append(details, step.StepError)
+		}
+		for _, log := range step.StepDbtLogs {
+			if log.Level == "error" && log.Msg != "" {
+				details = append(details, log.Msg)
+			}
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Project job step failed: %s", step.StepLabel),
+			Detail:   strings.Join(details, "\n"),
+		})
+	}
+	if len(diags) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Project job (%s) failed", job.JobUUID),
+		})
+	}
+
+	return diags
+}
@@ -0,0 +1,138 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testLightdashServer is a minimal in-memory stand-in for the Lightdash
+// API, the routes of each resource are served from that resource's tests
+type testLightdashServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	projects map[string]map[string]interface{}
+	groups   map[string]map[string]interface{}
+	access   map[string][]map[string]interface{}
+	spaces   map[string]map[string]interface{}
+	users    []map[string]interface{}
+	requests []string
+
+	// Jobs report running on the first poll, then finish, failing with a
+	// dbt error when failJobs is set
+	jobPolls map[string]int
+	failJobs bool
+}
+
+func newTestLightdashServer(t *testing.T) *testLightdashServer {
+	s := &testLightdashServer{
+		projects: map[string]map[string]interface{}{},
+		groups:   map[string]map[string]interface{}{},
+		access:   map[string][]map[string]interface{}{},
+		spaces:   map[string]map[string]interface{}{},
+		jobPolls: map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testLightdashServer) client(t *testing.T) *lightdash.Client {
+	token := "test-token"
+	client, err := lightdash.NewClient(context.Background(), &s.URL, nil, nil, &token)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+	return client
+}
+
+func (s *testLightdashServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+	parts := strings.Split(path, "/")
+	body, _ := ioutil.ReadAll(r.Body)
+
+	switch {
+	case path == "/org/users" || strings.HasPrefix(path, "/org/user/"):
+		s.handleUser(w, r.Method, parts)
+	case path == "/org/groups" || strings.HasPrefix(path, "/groups/"):
+		s.handleGroup(w, r.Method, parts, body)
+	case strings.HasPrefix(path, "/projects/") && strings.Contains(path, "/spaces/"):
+		s.handleSpace(w, r.Method, parts, body)
+	case strings.HasPrefix(path, "/projects/") && strings.HasSuffix(path, "/access"):
+		s.handleProjectAccess(w, r.Method, parts, body)
+	case strings.HasPrefix(path, "/projects/") && strings.HasSuffix(path, "/refresh"):
+		s.handleProjectRefresh(w, r.Method)
+	case strings.HasPrefix(path, "/jobs/"):
+		s.handleJob(w, r.Method, parts)
+	case path == "/org/projects" || strings.HasPrefix(path, "/org/projects/") || strings.HasPrefix(path, "/projects/"):
+		s.handleProject(w, r.Method, parts, body)
+	default:
+		http.Error(w, "unexpected request", http.StatusNotImplemented)
+	}
+}
+
+func (s *testLightdashServer) respond(w http.ResponseWriter, results interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok", "results": results})
+}
+
+// testResourceApply plans the config against the state and applies it,
+// returning the new state
+func testResourceApply(t *testing.T, r *schema.Resource, state *terraform.InstanceState, raw map[string]interface{}, meta interface{}) *terraform.InstanceState {
+	t.Helper()
+
+	newState, diags := testResourceApplyDiags(t, r, state, raw, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error applying: %v", diags)
+	}
+	return newState
+}
+
+func testResourceApplyDiags(t *testing.T, r *schema.Resource, state *terraform.InstanceState, raw map[string]interface{}, meta interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatalf("unexpected error planning: %s", err)
+	}
+	return r.Apply(ctx, state, diff, meta)
+}
+
+// testResourcePlanIsEmpty refreshes the state and fails if planning the
+// same config again would make any changes
+func testResourcePlanIsEmpty(t *testing.T, r *schema.Resource, state *terraform.InstanceState, raw map[string]interface{}, meta interface{}) {
+	t.Helper()
+	ctx := context.Background()
+
+	state, diags := r.RefreshWithoutUpgrade(ctx, state, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error refreshing: %v", diags)
+	}
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatalf("unexpected error planning: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		for key, attribute := range diff.Attributes {
+			t.Errorf("unexpected change to %s: %q => %q", key, attribute.Old, attribute.New)
+		}
+		t.FailNow()
+	}
+}
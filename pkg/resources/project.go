@@ -2,6 +2,10 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -15,11 +19,61 @@ var (
 		"DEVELOPMENT",
 	}
 	wareHouseTypes = []string{
+		"bigquery",
+		"clickhouse",
 		"databricks",
+		"postgres",
+		"redshift",
 		"snowflake",
+		"trino",
+	}
+	databricksAuthenticationTypes = []string{
+		"personal_access_token",
+		"oauth_m2m",
+	}
+	snowflakeAuthenticationTypes = []string{
+		"password",
+		"private_key",
+		"sso",
+	}
+	httpSchemes = []string{
+		"https",
+		"http",
+	}
+	sslModes = []string{
+		"disable",
+		"no-verify",
+		"allow",
+		"prefer",
+		"require",
+		"verify-ca",
+		"verify-full",
+	}
+	bigqueryPriorities = []string{
+		"interactive",
+		"batch",
 	}
 	dbtConnectionTypes = []string{
+		"azure_devops",
+		"bitbucket",
+		"dbt_cloud_ide",
 		"github",
+		"gitlab",
+	}
+	gitDbtConnectionTypes = []string{
+		"azure_devops",
+		"bitbucket",
+		"github",
+		"gitlab",
+	}
+	dbtBlocks = []string{
+		"dbt_cloud_ide",
+		"dbt_git",
+	}
+	dbtConnectionDefaultHostDomains = map[string]string{
+		"bitbucket": "bitbucket.org",
+		"github":    "github.com",
+		"gitlab":    "gitlab.com",
 	}
 )
 
@@ -47,110 +101,566 @@ var projectSchema = map[string]*schema.Schema{
 		Default:     "v1.8",
 		Description: "dbt version, defaults to v1.8",
 	},
-	"dbt_connection_type": &schema.Schema{
-		Type:         schema.TypeString,
-		Optional:     true,
-		Default:      "github",
-		Description:  "dbt project connection type, currently only support 'github', which is the default",
-		ValidateFunc: validation.StringInSlice(dbtConnectionTypes, false),
-	},
-	"dbt_connection_repository": &schema.Schema{
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Repository name in <org>/<repo> format",
-	},
-	"dbt_connection_branch": &schema.Schema{
-		Type:        schema.TypeString,
+	"wait_for_compile": &schema.Schema{
+		Type:        schema.TypeBool,
 		Optional:    true,
-		Default:     "main",
-		Description: "Branch to use, default 'main'",
+		Default:     false,
+		Description: "Wait for the dbt project to compile after the project is created or updated, failing with the dbt errors if it does not compile, default `false`",
 	},
-	"dbt_connection_project_sub_path": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Default:     "/",
-		Description: "Sub path to find the project in the repo, default '/'",
+	"dbt_git": &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		Description:  "dbt project hosted in a git repository, conflicts with `dbt_cloud_ide`",
+		ExactlyOneOf: dbtBlocks,
+		Elem:         dbtGitResource,
 	},
-	"dbt_connection_host_domain": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Default:     "github.com",
-		Description: "Host domain of the repo, default 'github.com'",
+	"dbt_cloud_ide": &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		Description:  "dbt project hosted in dbt Cloud, conflicts with `dbt_git`",
+		ExactlyOneOf: dbtBlocks,
+		Elem:         dbtCloudIDEResource,
 	},
-	"dbt_connection_personal_access_token": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Personal access token to authenticate with Git provider",
+	"snowflake": &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		Description:  "Snowflake warehouse connection, exactly one warehouse connection must be configured",
+		ExactlyOneOf: wareHouseTypes,
+		Elem:         snowflakeResource,
 	},
-	"warehouse_connection_type": &schema.Schema{
-		Type:         schema.TypeString,
+	"databricks": &schema.Schema{
+		Type:         schema.TypeList,
 		Optional:     true,
-		Default:      "snowflake",
-		Description:  "Type of warehouse to connect to, must be one of 'snowflake' or 'databricks', 'snowflake' is the default",
-		ValidateFunc: validation.StringInSlice(wareHouseTypes, false),
+		MaxItems:     1,
+		Description:  "Databricks warehouse connection, exactly one warehouse connection must be configured",
+		ExactlyOneOf: wareHouseTypes,
+		Elem:         databricksResource,
 	},
-	"databricks_connection_server_host_name": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Databricks - Server host name for connection",
+	"bigquery": &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		Description:  "BigQuery warehouse connection, exactly one warehouse connection must be configured",
+		ExactlyOneOf: wareHouseTypes,
+		Elem:         bigqueryResource,
 	},
-	"databricks_connection_http_path": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Databricks - HTTP path for connection",
+	"postgres": &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		Description:  "Postgres warehouse connection, exactly one warehouse connection must be configured",
+		ExactlyOneOf: wareHouseTypes,
+		Elem:         postgresResource,
 	},
-	"databricks_connection_personal_access_token": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Databricks - Personal access token for connection",
+	"redshift": &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		Description:  "Redshift warehouse connection, exactly one warehouse connection must be configured",
+		ExactlyOneOf: wareHouseTypes,
+		Elem:         redshiftResource,
 	},
-	"databricks_connection_catalog": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Databricks - Catalog name for connection",
+	"trino": &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		Description:  "Trino warehouse connection, exactly one warehouse connection must be configured",
+		ExactlyOneOf: wareHouseTypes,
+		Elem:         trinoResource,
 	},
-	"databricks_connection_schema": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Databricks - Schema name for connection",
+	"clickhouse": &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		Description:  "ClickHouse warehouse connection, exactly one warehouse connection must be configured",
+		ExactlyOneOf: wareHouseTypes,
+		Elem:         clickhouseResource,
 	},
-	"warehouse_connection_account": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Snowflake - Account identifier, including region/ cloud path",
+}
+
+var dbtGitResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"type": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "github",
+			Description:  "Git provider, one of 'github', 'gitlab', 'bitbucket' or 'azure_devops', 'github' is the default",
+			ValidateFunc: validation.StringInSlice(gitDbtConnectionTypes, false),
+		},
+		"repository": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Repository name in <org>/<repo> format, or just the repository name for 'azure_devops'",
+		},
+		"branch": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "main",
+			Description: "Branch to use, default 'main'",
+		},
+		"project_sub_path": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "/",
+			Description: "Sub path to find the project in the repo, default '/'",
+		},
+		"host_domain": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Host domain of the repo, defaults to 'github.com', 'gitlab.com' or 'bitbucket.org' depending on the type, not used for 'azure_devops'",
+		},
+		"personal_access_token": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Personal access token to authenticate with the git provider, or the app password for 'bitbucket', write-only and not read back from Lightdash",
+		},
+		"username": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Username to authenticate with, required for and only used by 'bitbucket'",
+		},
+		"organization": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Organization the repo belongs to, required for and only used by 'azure_devops'",
+		},
+		"project": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Project the repo belongs to, required for and only used by 'azure_devops'",
+		},
 	},
-	"warehouse_connection_role": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Snowflake - Role to connect to the warehouse with",
+}
+
+var dbtCloudIDEResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"api_key": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "dbt Cloud service token, write-only and not read back from Lightdash",
+		},
+		"environment_id": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "dbt Cloud environment ID",
+		},
+		"discovery_api_endpoint": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "dbt Cloud discovery API endpoint, defaults to the dbt Cloud multi-tenant endpoint",
+		},
+		"tags": &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "dbt Cloud tags used to filter the models to include",
+		},
 	},
-	"warehouse_connection_database": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Snowflake - Database to connect to",
+}
+
+var snowflakeResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"account": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Account identifier, including region/ cloud path",
+		},
+		"role": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Role to connect to the warehouse with",
+		},
+		"database": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Database to connect to",
+		},
+		"warehouse": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Warehouse to use",
+		},
+		"schema": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "PUBLIC",
+			Description: "Schema to connect to, default 'PUBLIC'",
+		},
+		"client_session_keep_alive": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Client session keep alive param, default `false`",
+		},
+		"threads": &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     1,
+			Description: "Number of threads to use, default `1`",
+		},
+		"authentication_type": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "password",
+			Description:  "Authentication method, one of 'password', 'private_key' or 'sso' (OAuth), default 'password'",
+			ValidateFunc: validation.StringInSlice(snowflakeAuthenticationTypes, false),
+		},
+		"user": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "User to connect as, not read back from Lightdash",
+		},
+		"password": &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			Sensitive:     true,
+			Description:   "Password for the user, write-only and not read back from Lightdash",
+			ConflictsWith: []string{"snowflake.0.private_key"},
+		},
+		"private_key": &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			Sensitive:     true,
+			Description:   "PEM encoded private key for key-pair authentication, write-only and not read back from Lightdash",
+			ConflictsWith: []string{"snowflake.0.password"},
+		},
+		"private_key_passphrase": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			Description:  "Passphrase for an encrypted private key, write-only and not read back from Lightdash",
+			RequiredWith: []string{"snowflake.0.private_key"},
+		},
+		"query_tag": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Query tag added to all queries run by Lightdash",
+		},
+		"access_url": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Custom access URL, e.g. for private link connections",
+		},
 	},
-	"warehouse_connection_schema": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Default:     "PUBLIC",
-		Description: "Snowflake - Schema to connect to, default 'PUBLIC'",
+}
+
+var databricksResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"server_host_name": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Server host name for connection",
+		},
+		"http_path": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "HTTP path of the default SQL warehouse or cluster",
+		},
+		"authentication_type": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "personal_access_token",
+			Description:  "Authentication method, one of 'personal_access_token' or 'oauth_m2m' (service principal client credentials), default 'personal_access_token'",
+			ValidateFunc: validation.StringInSlice(databricksAuthenticationTypes, false),
+		},
+		"personal_access_token": &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			Sensitive:     true,
+			Description:   "Personal access token for connection, write-only and not read back from Lightdash",
+			ConflictsWith: []string{"databricks.0.oauth_client_secret"},
+		},
+		"oauth_client_id": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Client ID of the service principal, required for 'oauth_m2m'",
+			RequiredWith: []string{"databricks.0.oauth_client_secret"},
+		},
+		"oauth_client_secret": &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			Sensitive:     true,
+			Description:   "Client secret of the service principal, required for 'oauth_m2m', write-only and not read back from Lightdash",
+			RequiredWith:  []string{"databricks.0.oauth_client_id"},
+			ConflictsWith: []string{"databricks.0.personal_access_token"},
+		},
+		"catalog": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Catalog name for connection",
+		},
+		"schema": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Schema name for connection",
+		},
+		"compute": &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Additional SQL warehouses or clusters that can be selected to run queries",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": &schema.Schema{
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Name shown when selecting the compute",
+					},
+					"http_path": &schema.Schema{
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "HTTP path of the SQL warehouse or cluster",
+					},
+				},
+			},
+		},
+		"start_of_week": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "First day of the week used for week based date truncation, one of monday/ tuesday/ wednesday/ thursday/ friday/ saturday/ sunday, the warehouse default when not set",
+			ValidateFunc: validation.StringInSlice(lightdash.WeekDays, false),
+		},
+	},
+}
+
+var bigqueryResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"project": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "GCP project ID to run queries in",
+		},
+		"dataset": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Default dataset for the connection",
+		},
+		"location": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Location of the dataset, e.g. 'EU' or 'us-central1'",
+		},
+		"priority": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "interactive",
+			Description:  "Query priority, one of 'interactive' or 'batch', default 'interactive'",
+			ValidateFunc: validation.StringInSlice(bigqueryPriorities, false),
+		},
+		"timeout_seconds": &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     300,
+			Description: "Query timeout in seconds, default `300`",
+		},
+		"retries": &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     3,
+			Description: "Number of times to retry a failed query, default `3`",
+		},
+		"maximum_bytes_billed": &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Maximum bytes a query can bill before failing, unlimited when not set",
+		},
+		"keyfile_contents": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			Description:  "Service account keyfile contents as a JSON string, write-only and not read back from Lightdash",
+			ValidateFunc: validation.StringIsJSON,
+		},
 	},
-	"warehouse_connection_client_session_keep_alive": &schema.Schema{
+}
+
+var postgresResource = &schema.Resource{
+	Schema: postgresConnectionSchema(5432),
+}
+
+var redshiftResource = &schema.Resource{
+	Schema: redshiftConnectionSchema(),
+}
+
+// Postgres and Redshift share the same connection settings, only the
+// default port differs
+func postgresConnectionSchema(defaultPort int) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Host name of the database server",
+		},
+		"port": &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     defaultPort,
+			Description: fmt.Sprintf("Port of the database server, default `%d`", defaultPort),
+		},
+		"user": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "User to connect as, not read back from Lightdash",
+		},
+		"password": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Password for the user, write-only and not read back from Lightdash",
+		},
+		"dbname": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Database name to connect to",
+		},
+		"schema": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Schema to connect to",
+		},
+		"sslmode": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "prefer",
+			Description:  "SSL mode, one of disable/ no-verify/ allow/ prefer/ require/ verify-ca/ verify-full, default 'prefer'",
+			ValidateFunc: validation.StringInSlice(sslModes, false),
+		},
+		"keepalives_idle": &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     0,
+			Description: "Seconds of inactivity before sending a keepalive, `0` uses the system default",
+		},
+		"use_ssh_tunnel": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Connect through an SSH tunnel, default `false`",
+		},
+		"ssh_tunnel_host": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Host name of the SSH tunnel",
+		},
+		"ssh_tunnel_port": &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     22,
+			Description: "Port of the SSH tunnel, default `22`",
+		},
+		"ssh_tunnel_user": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "User to connect to the SSH tunnel as",
+		},
+		"ssh_tunnel_public_key": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Public key generated by Lightdash, to be added to the SSH tunnel's authorized keys",
+		},
+	}
+}
+
+func redshiftConnectionSchema() map[string]*schema.Schema {
+	redshiftSchema := postgresConnectionSchema(5439)
+	redshiftSchema["ra3_node"] = &schema.Schema{
 		Type:        schema.TypeBool,
 		Optional:    true,
 		Default:     false,
-		Description: "Snowflake - Client session keep alive param, default `false`",
-	},
-	"warehouse_connection_warehouse": &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Snowflake - Warehouse to use",
+		Description: "Whether the cluster uses RA3 nodes, enabling cross-database queries, default `false`",
+	}
+	return redshiftSchema
+}
+
+var trinoResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"host": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Host name of the coordinator",
+		},
+		"port": &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     443,
+			Description: "Port of the coordinator, default `443`",
+		},
+		"user": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "User to connect as, not read back from Lightdash",
+		},
+		"password": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Password for the user, write-only and not read back from Lightdash",
+		},
+		"catalog": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Catalog to connect to",
+		},
+		"schema": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Schema to connect to",
+		},
+		"http_scheme": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "https",
+			Description:  "HTTP scheme to connect with, one of 'https' or 'http', default 'https'",
+			ValidateFunc: validation.StringInSlice(httpSchemes, false),
+		},
 	},
-	"warehouse_connection_threads": &schema.Schema{
-		Type:        schema.TypeInt,
-		Optional:    true,
-		Default:     1,
-		Description: "Snowflake - Number of threads to use, default `1`",
+}
+
+var clickhouseResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"host": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Host name of the server",
+		},
+		"port": &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     8443,
+			Description: "HTTP(S) port of the server, default `8443`",
+		},
+		"user": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "User to connect as, not read back from Lightdash",
+		},
+		"password": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Password for the user, write-only and not read back from Lightdash",
+		},
+		"schema": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Database to use as the schema",
+		},
+		"secure": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Connect over HTTPS, default `true`",
+		},
+		"timeout_seconds": &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     300,
+			Description: "Query timeout in seconds, default `300`",
+		},
 	},
 }
 
@@ -160,11 +670,25 @@ func ResourceProject() *schema.Resource {
 		ReadContext:   resourceProjectRead,
 		UpdateContext: resourceProjectUpdate,
 		DeleteContext: resourceProjectDelete,
+		CustomizeDiff: resourceProjectCustomizeDiff,
 
 		Schema: projectSchema,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceProjectV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceProjectStateUpgradeV0,
+				Version: 0,
+			},
+		},
 	}
 }
 
@@ -175,7 +699,12 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 	projectID := d.Id()
 
-	project, err := c.GetProject(projectID)
+	project, err := c.GetProject(ctx, projectID)
+	if lightdash.IsNotFound(err) {
+		// The project has been deleted outside of Terraform
+		d.SetId("")
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -192,56 +721,34 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 	if err := d.Set("dbt_version", project.DbtVersion); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("dbt_connection_type", project.DbtConnection.Type); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("dbt_connection_repository", project.DbtConnection.Repository); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("dbt_connection_branch", project.DbtConnection.Branch); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("dbt_connection_project_sub_path", project.DbtConnection.ProjectSubPath); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("dbt_connection_host_domain", project.DbtConnection.HostDomain); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("warehouse_connection_type", project.WarehouseConnection.Type); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("databricks_connection_server_host_name", project.WarehouseConnection.ServerHostName); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("databricks_connection_http_path", project.WarehouseConnection.HTTPPath); err != nil {
+	// Not stored by Lightdash, this makes sure the default is set on import
+	if err := d.Set("wait_for_compile", d.Get("wait_for_compile").(bool)); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("databricks_connection_catalog", project.WarehouseConnection.Catalog); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("databricks_connection_schema", project.WarehouseConnection.Schema); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("warehouse_connection_account", project.WarehouseConnection.Account); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("warehouse_connection_role", project.WarehouseConnection.Role); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("warehouse_connection_database", project.WarehouseConnection.Database); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("warehouse_connection_schema", project.WarehouseConnection.Schema); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("warehouse_connection_client_session_keep_alive", project.WarehouseConnection.ClientSessionKeepAlive); err != nil {
-		return diag.FromErr(err)
+
+	// Only the block matching the connection type is set, so a type changed
+	// outside of Terraform shows up as a diff
+	dbtBlock := "dbt_git"
+	if project.DbtConnection.Type == "dbt_cloud_ide" {
+		dbtBlock = "dbt_cloud_ide"
 	}
-	if err := d.Set("warehouse_connection_warehouse", project.WarehouseConnection.Warehouse); err != nil {
-		return diag.FromErr(err)
+	for _, block := range dbtBlocks {
+		value := []interface{}{}
+		if block == dbtBlock {
+			value = append(value, flattenDbtConnection(d, project.DbtConnection))
+		}
+		if err := d.Set(block, value); err != nil {
+			return diag.FromErr(err)
+		}
 	}
-	if err := d.Set("warehouse_connection_threads", project.WarehouseConnection.Threads); err != nil {
-		return diag.FromErr(err)
+	for _, block := range wareHouseTypes {
+		value := []interface{}{}
+		if block == project.WarehouseConnection.Type {
+			value = append(value, flattenWarehouseConnection(d, project.WarehouseConnection))
+		}
+		if err := d.Set(block, value); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return diags
@@ -255,96 +762,42 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 	name := d.Get("name").(string)
 	organizationUUID := d.Get("organization_uuid").(string)
 	projectType := d.Get("type").(string)
-	dbtConnectionType := d.Get("dbt_connection_type").(string)
 	dbtVersion := d.Get("dbt_version").(string)
-	dbtConnectionRepository := d.Get("dbt_connection_repository").(string)
-	dbtConnectionBranch := d.Get("dbt_connection_branch").(string)
-	dbtConnectionProjectSubPath := d.Get("dbt_connection_project_sub_path").(string)
-	dbtConnectionHostDomain := d.Get("dbt_connection_host_domain").(string)
-	dbtConnectionPersonalAccessToken := d.Get("dbt_connection_personal_access_token").(string)
-	warehouseConnectionType := d.Get("warehouse_connection_type").(string)
-	warehouseConnectionAccount := d.Get("warehouse_connection_account").(string)
-	warehouseConnectionRole := d.Get("warehouse_connection_role").(string)
-	warehouseConnectionDatabase := d.Get("warehouse_connection_database").(string)
-	warehouseConnectionSchema := d.Get("warehouse_connection_schema").(string)
-	warehouseConnectionClientSessionKeepAlive := d.Get("warehouse_connection_client_session_keep_alive").(bool)
-	warehouseConnectionWarehouse := d.Get("warehouse_connection_warehouse").(string)
-	warehouseConnectionThreads := d.Get("warehouse_connection_threads").(int)
-	databricksConnectionServerHostName := d.Get("databricks_connection_server_host_name").(string)
-	databricksConnectionHttpPath := d.Get("databricks_connection_http_path").(string)
-	databricksConnectionPersonalAccessToken := d.Get("databricks_connection_personal_access_token").(string)
-	databricksConnectionCatalog := d.Get("databricks_connection_catalog").(string)
-	databricksConnectionSchema := d.Get("databricks_connection_schema").(string)
-
-	dbtConnection := lightdash.DbtConnection{
-		Type:                dbtConnectionType,
-		Repository:          dbtConnectionRepository,
-		Branch:              dbtConnectionBranch,
-		ProjectSubPath:      dbtConnectionProjectSubPath,
-		HostDomain:          dbtConnectionHostDomain,
-		PersonalAccessToken: dbtConnectionPersonalAccessToken,
-	}
-	warehouseConnection := lightdash.WarehouseConnection{
-		Type: warehouseConnectionType,
-	}
 
-	if warehouseConnection.Type == "snowflake" {
-		warehouseConnection.Account = warehouseConnectionAccount
-		warehouseConnection.Role = warehouseConnectionRole
-		warehouseConnection.Database = warehouseConnectionDatabase
-		warehouseConnection.Warehouse = warehouseConnectionWarehouse
-		warehouseConnection.Schema = warehouseConnectionSchema
-		warehouseConnection.ClientSessionKeepAlive = warehouseConnectionClientSessionKeepAlive
-		warehouseConnection.Threads = warehouseConnectionThreads
-	}
-	if warehouseConnection.Type == "databricks" {
-		warehouseConnection.ServerHostName = databricksConnectionServerHostName
-		warehouseConnection.HTTPPath = databricksConnectionHttpPath
-		warehouseConnection.PersonalAccessToken = databricksConnectionPersonalAccessToken
-		warehouseConnection.Catalog = databricksConnectionCatalog
-		warehouseConnection.Database = databricksConnectionSchema
+	dbtConnection := expandDbtConnection(d)
+	warehouseConnection, err := expandWarehouseConnection(d)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	project, err := c.CreateProject(organizationUUID, name, projectType, dbtVersion, dbtConnection, warehouseConnection)
+	project, err := c.CreateProject(ctx, organizationUUID, name, projectType, dbtVersion, dbtConnection, warehouseConnection)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId(project.ProjectUUID)
 
-	resourceProjectRead(ctx, d, m)
+	// Projects are created without being compiled, so start a compile to wait on
+	if d.Get("wait_for_compile").(bool) {
+		jobUUID, err := c.RefreshProject(ctx, project.ProjectUUID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_, jobDiags := waitForProjectJob(ctx, c, jobUUID, d.Timeout(schema.TimeoutCreate))
+		diags = append(diags, jobDiags...)
+	}
 
-	return diags
+	return append(diags, resourceProjectRead(ctx, d, m)...)
 }
 
 func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*lightdash.Client)
 	projectID := d.Id()
 
-	hasChange := false
-	for _, x1 := range []string{"name", "dbt_version"} {
-		if d.HasChange(x1) {
-			hasChange = true
-		}
-	}
-	for _, x2 := range []string{"dbt_connection_type", "dbt_connection_repository", "dbt_connection_branch", "dbt_connection_project_sub_path", "dbt_connection_host_domain", "dbt_connection_personal_access_token"} {
-		if d.HasChange(x2) {
-			hasChange = true
-		}
-	}
-	for _, x3 := range []string{"warehouse_connection_type", "warehouse_connection_account", "warehouse_connection_role", "warehouse_connection_database", "warehouse_connection_schema", "warehouse_connection_client_session_keep_alive", "warehouse_connection_warehouse", "warehouse_connection_threads"} {
-		if d.HasChange(x3) {
-			hasChange = true
-		}
-	}
-	for _, x4 := range []string{"databricks_connection_server_host_name", "databricks_connection_http_path", "databricks_connection_personal_access_token", "databricks_connection_catalog", "databricks_connection_schema"} {
-		if d.HasChange(x4) {
-			hasChange = true
-		}
-	}
+	var diags diag.Diagnostics
 
-	if hasChange {
-		project, err := c.GetProject(projectID)
+	if d.HasChanges("name", "dbt_version") || d.HasChanges(dbtBlocks...) || d.HasChanges(wareHouseTypes...) {
+		project, err := c.GetProject(ctx, projectID)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -354,71 +807,414 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		if d.HasChange("dbt_version") {
 			project.DbtVersion = d.Get("dbt_version").(string)
 		}
-		if d.HasChange("dbt_connection_type") {
-			project.DbtConnection.Type = d.Get("dbt_connection_type").(string)
+		// A changed block replaces the whole connection, so settings of a
+		// previous connection type are not carried over
+		if d.HasChanges(dbtBlocks...) {
+			project.DbtConnection = expandDbtConnection(d)
 		}
-		if d.HasChange("dbt_connection_repository") {
-			project.DbtConnection.Repository = d.Get("dbt_connection_repository").(string)
+		if d.HasChanges(wareHouseTypes...) {
+			warehouseConnection, err := expandWarehouseConnection(d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			project.WarehouseConnection = warehouseConnection
 		}
-		if d.HasChange("dbt_connection_branch") {
-			project.DbtConnection.Branch = d.Get("dbt_connection_branch").(string)
+
+		jobUUID, err := c.UpdateProject(ctx, projectID, project.Name, project.DbtVersion, project.DbtConnection, project.WarehouseConnection)
+		if err != nil {
+			return diag.FromErr(err)
 		}
-		if d.HasChange("dbt_connection_project_sub_path") {
-			project.DbtConnection.ProjectSubPath = d.Get("dbt_connection_project_sub_path").(string)
+
+		if d.Get("wait_for_compile").(bool) && jobUUID != "" {
+			_, jobDiags := waitForProjectJob(ctx, c, jobUUID, d.Timeout(schema.TimeoutUpdate))
+			diags = append(diags, jobDiags...)
 		}
-		if d.HasChange("dbt_connection_host_domain") {
-			project.DbtConnection.HostDomain = d.Get("dbt_connection_host_domain").(string)
+	}
+
+	return append(diags, resourceProjectRead(ctx, d, m)...)
+}
+
+func resourceProjectCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if len(d.Get("databricks").([]interface{})) > 0 && d.NewValueKnown("databricks.0.authentication_type") {
+		authenticationType := d.Get("databricks.0.authentication_type").(string)
+		if authenticationType == "oauth_m2m" && d.NewValueKnown("databricks.0.oauth_client_id") && d.Get("databricks.0.oauth_client_id").(string) == "" {
+			return fmt.Errorf("databricks.oauth_client_id and databricks.oauth_client_secret are required when authentication_type is '%s'", authenticationType)
 		}
-		if d.HasChange("dbt_connection_personal_access_token") {
-			project.DbtConnection.PersonalAccessToken = d.Get("dbt_connection_personal_access_token").(string)
+		if authenticationType != "oauth_m2m" && d.Get("databricks.0.oauth_client_id").(string) != "" {
+			return fmt.Errorf("databricks.oauth_client_id can not be set when authentication_type is '%s'", authenticationType)
 		}
-		if d.HasChange("warehouse_connection_type") {
-			project.WarehouseConnection.Type = d.Get("warehouse_connection_type").(string)
+	}
+
+	if len(d.Get("dbt_git").([]interface{})) == 0 || !d.NewValueKnown("dbt_git.0.type") {
+		return nil
+	}
+	dbtConnectionType := d.Get("dbt_git.0.type").(string)
+
+	// Each attribute is only valid for, and required by, the given git providers
+	requiredBy := map[string][]string{
+		"username":     {"bitbucket"},
+		"organization": {"azure_devops"},
+		"project":      {"azure_devops"},
+	}
+	for attribute, connectionTypes := range requiredBy {
+		key := "dbt_git.0." + attribute
+		if !d.NewValueKnown(key) {
+			continue
 		}
-		if d.HasChange("warehouse_connection_account") {
-			project.WarehouseConnection.Account = d.Get("warehouse_connection_account").(string)
+		value := d.Get(key).(string)
+		isValidType := false
+		for _, connectionType := range connectionTypes {
+			if dbtConnectionType == connectionType {
+				isValidType = true
+			}
 		}
-		if d.HasChange("warehouse_connection_role") {
-			project.WarehouseConnection.Role = d.Get("warehouse_connection_role").(string)
+		if isValidType && value == "" {
+			return fmt.Errorf("dbt_git.%s is required when type is '%s'", attribute, dbtConnectionType)
 		}
-		if d.HasChange("warehouse_connection_database") {
-			project.WarehouseConnection.Database = d.Get("warehouse_connection_database").(string)
+		if !isValidType && value != "" {
+			return fmt.Errorf("dbt_git.%s can not be set when type is '%s'", attribute, dbtConnectionType)
 		}
-		if d.HasChange("warehouse_connection_schema") {
-			project.WarehouseConnection.Schema = d.Get("warehouse_connection_schema").(string)
+	}
+
+	if dbtConnectionType == "azure_devops" && d.Get("dbt_git.0.host_domain").(string) != "" {
+		return fmt.Errorf("dbt_git.host_domain can not be set when type is '%s'", dbtConnectionType)
+	}
+
+	return nil
+}
+
+// Returns the attributes of a single item block and whether it is set
+func expandBlock(d *schema.ResourceData, key string) (map[string]interface{}, bool) {
+	blocks := d.Get(key).([]interface{})
+	if len(blocks) == 0 {
+		return nil, false
+	}
+	if blocks[0] == nil {
+		return map[string]interface{}{}, true
+	}
+	return blocks[0].(map[string]interface{}), true
+}
+
+func expandDbtConnection(d *schema.ResourceData) lightdash.DbtConnection {
+	if block, ok := expandBlock(d, "dbt_cloud_ide"); ok {
+		return lightdash.DbtConnection{
+			Type:                 "dbt_cloud_ide",
+			APIKey:               block["api_key"].(string),
+			EnvironmentID:        block["environment_id"].(string),
+			DiscoveryAPIEndpoint: block["discovery_api_endpoint"].(string),
+			Tags:                 expandStringList(block["tags"].([]interface{})),
 		}
-		if d.HasChange("warehouse_connection_client_session_keep_alive") {
-			project.WarehouseConnection.ClientSessionKeepAlive = d.Get("warehouse_connection_client_session_keep_alive").(bool)
+	}
+
+	block, _ := expandBlock(d, "dbt_git")
+	dbtConnection := lightdash.DbtConnection{
+		Type:                block["type"].(string),
+		Repository:          block["repository"].(string),
+		Branch:              block["branch"].(string),
+		ProjectSubPath:      block["project_sub_path"].(string),
+		HostDomain:          block["host_domain"].(string),
+		PersonalAccessToken: block["personal_access_token"].(string),
+	}
+	if dbtConnection.HostDomain == "" {
+		dbtConnection.HostDomain = dbtConnectionDefaultHostDomains[dbtConnection.Type]
+	}
+	if dbtConnection.Type == "bitbucket" {
+		dbtConnection.Username = block["username"].(string)
+	}
+	if dbtConnection.Type == "azure_devops" {
+		dbtConnection.Organization = block["organization"].(string)
+		dbtConnection.Project = block["project"].(string)
+	}
+	return dbtConnection
+}
+
+// Secrets are never returned by the API, so they are kept as configured
+func flattenDbtConnection(d *schema.ResourceData, dbtConnection lightdash.DbtConnection) map[string]interface{} {
+	if dbtConnection.Type == "dbt_cloud_ide" {
+		return map[string]interface{}{
+			"api_key":                d.Get("dbt_cloud_ide.0.api_key").(string),
+			"environment_id":         dbtConnection.EnvironmentID,
+			"discovery_api_endpoint": dbtConnection.DiscoveryAPIEndpoint,
+			"tags":                   dbtConnection.Tags,
 		}
-		if d.HasChange("warehouse_connection_warehouse") {
-			project.WarehouseConnection.Warehouse = d.Get("warehouse_connection_warehouse").(string)
+	}
+
+	// The default host domain is filled in on create, keep it unset unless
+	// it was configured
+	hostDomain := dbtConnection.HostDomain
+	if d.Get("dbt_git.0.host_domain").(string) == "" && hostDomain == dbtConnectionDefaultHostDomains[dbtConnection.Type] {
+		hostDomain = ""
+	}
+	return map[string]interface{}{
+		"type":                  dbtConnection.Type,
+		"repository":            dbtConnection.Repository,
+		"branch":                dbtConnection.Branch,
+		"project_sub_path":      dbtConnection.ProjectSubPath,
+		"host_domain":           hostDomain,
+		"personal_access_token": d.Get("dbt_git.0.personal_access_token").(string),
+		"username":              dbtConnection.Username,
+		"organization":          dbtConnection.Organization,
+		"project":               dbtConnection.Project,
+	}
+}
+
+func expandWarehouseConnection(d *schema.ResourceData) (lightdash.WarehouseConnection, error) {
+	if block, ok := expandBlock(d, "snowflake"); ok {
+		return lightdash.WarehouseConnection{
+			Type:                   "snowflake",
+			Account:                block["account"].(string),
+			Role:                   block["role"].(string),
+			Database:               block["database"].(string),
+			Warehouse:              block["warehouse"].(string),
+			Schema:                 block["schema"].(string),
+			ClientSessionKeepAlive: block["client_session_keep_alive"].(bool),
+			Threads:                block["threads"].(int),
+			AuthenticationType:     block["authentication_type"].(string),
+			User:                   block["user"].(string),
+			Password:               block["password"].(string),
+			PrivateKey:             block["private_key"].(string),
+			PrivateKeyPass:         block["private_key_passphrase"].(string),
+			QueryTag:               block["query_tag"].(string),
+			AccessURL:              block["access_url"].(string),
+		}, nil
+	}
+	if block, ok := expandBlock(d, "databricks"); ok {
+		// Lightdash calls the Databricks schema the database
+		warehouseConnection := lightdash.WarehouseConnection{
+			Type:               "databricks",
+			ServerHostName:     block["server_host_name"].(string),
+			HTTPPath:           block["http_path"].(string),
+			AuthenticationType: block["authentication_type"].(string),
+			Catalog:            block["catalog"].(string),
+			Database:           block["schema"].(string),
+			StartOfWeek:        lightdash.WeekDayIndex(block["start_of_week"].(string)),
 		}
-		if d.HasChange("warehouse_connection_threads") {
-			project.WarehouseConnection.Threads = d.Get("warehouse_connection_threads").(int)
+		if warehouseConnection.AuthenticationType == "oauth_m2m" {
+			warehouseConnection.OAuthClientID = block["oauth_client_id"].(string)
+			warehouseConnection.OAuthClientSecret = block["oauth_client_secret"].(string)
+		} else {
+			warehouseConnection.PersonalAccessToken = block["personal_access_token"].(string)
 		}
-		if d.HasChange("databricks_connection_server_host_name") {
-			project.WarehouseConnection.ServerHostName = d.Get("databricks_connection_server_host_name").(string)
+		for _, compute := range block["compute"].([]interface{}) {
+			compute := compute.(map[string]interface{})
+			warehouseConnection.Compute = append(warehouseConnection.Compute, lightdash.DatabricksCompute{
+				Name:     compute["name"].(string),
+				HTTPPath: compute["http_path"].(string),
+			})
 		}
-		if d.HasChange("databricks_connection_http_path") {
-			project.WarehouseConnection.HTTPPath = d.Get("databricks_connection_http_path").(string)
+		return warehouseConnection, nil
+	}
+	if block, ok := expandBlock(d, "bigquery"); ok {
+		keyfileContents, err := bigqueryKeyfileContents(block["keyfile_contents"].(string))
+		if err != nil {
+			return lightdash.WarehouseConnection{}, err
 		}
-		if d.HasChange("databricks_connection_personal_access_token") {
-			project.WarehouseConnection.PersonalAccessToken = d.Get("databricks_connection_personal_access_token").(string)
+		return lightdash.WarehouseConnection{
+			Type:               "bigquery",
+			Project:            block["project"].(string),
+			Dataset:            block["dataset"].(string),
+			Location:           block["location"].(string),
+			Priority:           block["priority"].(string),
+			TimeoutSeconds:     block["timeout_seconds"].(int),
+			Retries:            block["retries"].(int),
+			MaximumBytesBilled: block["maximum_bytes_billed"].(int),
+			KeyfileContents:    keyfileContents,
+		}, nil
+	}
+	if block, ok := expandBlock(d, "postgres"); ok {
+		return expandPostgresConnection("postgres", block), nil
+	}
+	if block, ok := expandBlock(d, "redshift"); ok {
+		warehouseConnection := expandPostgresConnection("redshift", block)
+		warehouseConnection.RA3Node = block["ra3_node"].(bool)
+		return warehouseConnection, nil
+	}
+	if block, ok := expandBlock(d, "trino"); ok {
+		return lightdash.WarehouseConnection{
+			Type:       "trino",
+			Host:       block["host"].(string),
+			Port:       block["port"].(int),
+			User:       block["user"].(string),
+			Password:   block["password"].(string),
+			DBName:     block["catalog"].(string),
+			Schema:     block["schema"].(string),
+			HTTPScheme: block["http_scheme"].(string),
+		}, nil
+	}
+	if block, ok := expandBlock(d, "clickhouse"); ok {
+		return lightdash.WarehouseConnection{
+			Type:           "clickhouse",
+			Host:           block["host"].(string),
+			Port:           block["port"].(int),
+			User:           block["user"].(string),
+			Password:       block["password"].(string),
+			Schema:         block["schema"].(string),
+			Secure:         block["secure"].(bool),
+			TimeoutSeconds: block["timeout_seconds"].(int),
+		}, nil
+	}
+	return lightdash.WarehouseConnection{}, fmt.Errorf("One of %s must be configured", strings.Join(wareHouseTypes, ", "))
+}
+
+func expandPostgresConnection(warehouseType string, block map[string]interface{}) lightdash.WarehouseConnection {
+	warehouseConnection := lightdash.WarehouseConnection{
+		Type:           warehouseType,
+		Host:           block["host"].(string),
+		Port:           block["port"].(int),
+		User:           block["user"].(string),
+		Password:       block["password"].(string),
+		DBName:         block["dbname"].(string),
+		Schema:         block["schema"].(string),
+		SSLMode:        block["sslmode"].(string),
+		KeepalivesIdle: block["keepalives_idle"].(int),
+		UseSSHTunnel:   block["use_ssh_tunnel"].(bool),
+	}
+	if warehouseConnection.UseSSHTunnel {
+		warehouseConnection.SSHTunnelHost = block["ssh_tunnel_host"].(string)
+		warehouseConnection.SSHTunnelPort = block["ssh_tunnel_port"].(int)
+		warehouseConnection.SSHTunnelUser = block["ssh_tunnel_user"].(string)
+	}
+	return warehouseConnection
+}
+
+// Secrets and the user are never returned by the API, so they are kept as
+// configured
+func flattenWarehouseConnection(d *schema.ResourceData, warehouseConnection lightdash.WarehouseConnection) map[string]interface{} {
+	prefix := warehouseConnection.Type + ".0."
+
+	switch warehouseConnection.Type {
+	case "snowflake":
+		// Projects created before authentication types were introduced use a password
+		authenticationType := warehouseConnection.AuthenticationType
+		if authenticationType == "" {
+			authenticationType = "password"
 		}
-		if d.HasChange("databricks_connection_catalog") {
-			project.WarehouseConnection.Catalog = d.Get("databricks_connection_catalog").(string)
+		return map[string]interface{}{
+			"account":                   warehouseConnection.Account,
+			"role":                      warehouseConnection.Role,
+			"database":                  warehouseConnection.Database,
+			"warehouse":                 warehouseConnection.Warehouse,
+			"schema":                    warehouseConnection.Schema,
+			"client_session_keep_alive": warehouseConnection.ClientSessionKeepAlive,
+			"threads":                   warehouseConnection.Threads,
+			"authentication_type":       authenticationType,
+			"user":                      d.Get(prefix + "user").(string),
+			"password":                  d.Get(prefix + "password").(string),
+			"private_key":               d.Get(prefix + "private_key").(string),
+			"private_key_passphrase":    d.Get(prefix + "private_key_passphrase").(string),
+			"query_tag":                 warehouseConnection.QueryTag,
+			"access_url":                warehouseConnection.AccessURL,
 		}
-		if d.HasChange("databricks_connection_schema") {
-			project.WarehouseConnection.Schema = d.Get("databricks_connection_schema").(string)
+	case "databricks":
+		// Projects created before authentication types were introduced use a token
+		authenticationType := warehouseConnection.AuthenticationType
+		if authenticationType == "" {
+			authenticationType = "personal_access_token"
 		}
-
-		_, err = c.UpdateProject(projectID, project.Name, project.DbtVersion, project.DbtConnection, project.WarehouseConnection)
-		if err != nil {
-			return diag.FromErr(err)
+		// The client ID is kept as configured when the API does not return it
+		oauthClientID := warehouseConnection.OAuthClientID
+		if oauthClientID == "" {
+			oauthClientID = d.Get(prefix + "oauth_client_id").(string)
+		}
+		compute := []interface{}{}
+		for _, c := range warehouseConnection.Compute {
+			compute = append(compute, map[string]interface{}{
+				"name":      c.Name,
+				"http_path": c.HTTPPath,
+			})
+		}
+		return map[string]interface{}{
+			"server_host_name":      warehouseConnection.ServerHostName,
+			"http_path":             warehouseConnection.HTTPPath,
+			"authentication_type":   authenticationType,
+			"personal_access_token": d.Get(prefix + "personal_access_token").(string),
+			"oauth_client_id":       oauthClientID,
+			"oauth_client_secret":   d.Get(prefix + "oauth_client_secret").(string),
+			"catalog":               warehouseConnection.Catalog,
+			"schema":                warehouseConnection.Database,
+			"compute":               compute,
+			"start_of_week":         lightdash.WeekDayName(warehouseConnection.StartOfWeek),
+		}
+	case "bigquery":
+		return map[string]interface{}{
+			"project":              warehouseConnection.Project,
+			"dataset":              warehouseConnection.Dataset,
+			"location":             warehouseConnection.Location,
+			"priority":             warehouseConnection.Priority,
+			"timeout_seconds":      warehouseConnection.TimeoutSeconds,
+			"retries":              warehouseConnection.Retries,
+			"maximum_bytes_billed": warehouseConnection.MaximumBytesBilled,
+			"keyfile_contents":     d.Get(prefix + "keyfile_contents").(string),
+		}
+	case "postgres", "redshift":
+		block := map[string]interface{}{
+			"host":                  warehouseConnection.Host,
+			"port":                  warehouseConnection.Port,
+			"user":                  d.Get(prefix + "user").(string),
+			"password":              d.Get(prefix + "password").(string),
+			"dbname":                warehouseConnection.DBName,
+			"schema":                warehouseConnection.Schema,
+			"sslmode":               warehouseConnection.SSLMode,
+			"keepalives_idle":       warehouseConnection.KeepalivesIdle,
+			"use_ssh_tunnel":        warehouseConnection.UseSSHTunnel,
+			"ssh_tunnel_host":       d.Get(prefix + "ssh_tunnel_host").(string),
+			"ssh_tunnel_port":       d.Get(prefix + "ssh_tunnel_port").(int),
+			"ssh_tunnel_user":       d.Get(prefix + "ssh_tunnel_user").(string),
+			"ssh_tunnel_public_key": warehouseConnection.SSHTunnelPublicKey,
+		}
+		// The tunnel settings are not sent unless the tunnel is used
+		if warehouseConnection.UseSSHTunnel {
+			block["ssh_tunnel_host"] = warehouseConnection.SSHTunnelHost
+			block["ssh_tunnel_port"] = warehouseConnection.SSHTunnelPort
+			block["ssh_tunnel_user"] = warehouseConnection.SSHTunnelUser
+		}
+		if warehouseConnection.Type == "redshift" {
+			block["ra3_node"] = warehouseConnection.RA3Node
 		}
+		return block
+	case "trino":
+		return map[string]interface{}{
+			"host":        warehouseConnection.Host,
+			"port":        warehouseConnection.Port,
+			"user":        d.Get(prefix + "user").(string),
+			"password":    d.Get(prefix + "password").(string),
+			"catalog":     warehouseConnection.DBName,
+			"schema":      warehouseConnection.Schema,
+			"http_scheme": warehouseConnection.HTTPScheme,
+		}
+	case "clickhouse":
+		return map[string]interface{}{
+			"host":            warehouseConnection.Host,
+			"port":            warehouseConnection.Port,
+			"user":            d.Get(prefix + "user").(string),
+			"password":        d.Get(prefix + "password").(string),
+			"schema":          warehouseConnection.Schema,
+			"secure":          warehouseConnection.Secure,
+			"timeout_seconds": warehouseConnection.TimeoutSeconds,
+		}
+	}
+	return nil
+}
+
+func expandStringList(list []interface{}) []string {
+	values := []string{}
+	for _, value := range list {
+		values = append(values, value.(string))
 	}
+	return values
+}
 
-	return resourceProjectRead(ctx, d, m)
+// The API expects the keyfile as an object, but it is configured as a JSON
+// string so it can be read from a file or secret store
+func bigqueryKeyfileContents(keyfile string) (map[string]interface{}, error) {
+	if keyfile == "" {
+		return nil, nil
+	}
+	keyfileContents := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(keyfile), &keyfileContents); err != nil {
+		return nil, err
+	}
+	return keyfileContents, nil
 }
 
 func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -428,7 +1224,7 @@ func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interf
 
 	var diags diag.Diagnostics
 
-	status, err := c.DeleteProject(projectID)
+	status, err := c.DeleteProject(ctx, projectID)
 	if (status != "ok") || (err != nil) {
 		return diag.FromErr(err)
 	}
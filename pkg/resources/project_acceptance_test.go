@@ -1,6 +1,7 @@
 package resources_test
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
@@ -15,6 +16,10 @@ func TestAccLightdashProjectResource(t *testing.T) {
 
 	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	nameDatabricks := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	nameBigquery := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	namePostgres := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	nameTrino := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	nameGitlab := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -41,7 +46,7 @@ func TestAccLightdashProjectResource(t *testing.T) {
 				ResourceName:            "lightdash_project.test_project",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{},
+				ImportStateVerifyIgnore: []string{"snowflake.0.password"},
 			},
 		},
 	})
@@ -64,6 +69,7 @@ func TestAccLightdashProjectResource(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckLightdashProjectExists("lightdash_project.test_databricks_project"),
 					resource.TestCheckResourceAttr("lightdash_project.test_databricks_project", "name", nameDatabricks),
+					resource.TestCheckResourceAttr("lightdash_project.test_databricks_project", "databricks.0.schema", "analytics"),
 				),
 			},
 			// IMPORT
@@ -71,10 +77,201 @@ func TestAccLightdashProjectResource(t *testing.T) {
 				ResourceName:            "lightdash_project.test_databricks_project",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{},
+				ImportStateVerifyIgnore: []string{"databricks.0.personal_access_token"},
 			},
 		},
 	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLightdashProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLightdashProjectResourceBigqueryConfig(nameBigquery, "analytics"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashProjectExists("lightdash_project.test_bigquery_project"),
+					resource.TestCheckResourceAttr("lightdash_project.test_bigquery_project", "name", nameBigquery),
+					resource.TestCheckResourceAttr("lightdash_project.test_bigquery_project", "bigquery.0.dataset", "analytics"),
+				),
+			},
+			// MODIFY
+			{
+				Config: testAccLightdashProjectResourceBigqueryConfig(nameBigquery, "marts"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashProjectExists("lightdash_project.test_bigquery_project"),
+					resource.TestCheckResourceAttr("lightdash_project.test_bigquery_project", "bigquery.0.dataset", "marts"),
+				),
+			},
+			// IMPORT
+			{
+				ResourceName:            "lightdash_project.test_bigquery_project",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"bigquery.0.keyfile_contents"},
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLightdashProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLightdashProjectResourcePostgresConfig(namePostgres, "postgres", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashProjectExists("lightdash_project.test_postgres_project"),
+					resource.TestCheckResourceAttr("lightdash_project.test_postgres_project", "postgres.0.port", "5432"),
+				),
+			},
+			// MODIFY
+			{
+				Config: testAccLightdashProjectResourcePostgresConfig(namePostgres, "redshift", "ra3_node = true"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashProjectExists("lightdash_project.test_postgres_project"),
+					resource.TestCheckResourceAttr("lightdash_project.test_postgres_project", "postgres.#", "0"),
+					resource.TestCheckResourceAttr("lightdash_project.test_postgres_project", "redshift.0.port", "5439"),
+					resource.TestCheckResourceAttr("lightdash_project.test_postgres_project", "redshift.0.ra3_node", "true"),
+				),
+			},
+			// IMPORT
+			{
+				ResourceName:            "lightdash_project.test_postgres_project",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"redshift.0.password"},
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLightdashProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLightdashProjectResourceTrinoConfig(nameTrino),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashProjectExists("lightdash_project.test_trino_project"),
+					resource.TestCheckResourceAttr("lightdash_project.test_trino_project", "trino.0.catalog", "hive"),
+				),
+			},
+			// MODIFY
+			{
+				Config: testAccLightdashProjectResourceClickhouseConfig(nameTrino),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashProjectExists("lightdash_project.test_trino_project"),
+					resource.TestCheckResourceAttr("lightdash_project.test_trino_project", "trino.#", "0"),
+					resource.TestCheckResourceAttr("lightdash_project.test_trino_project", "clickhouse.0.secure", "true"),
+				),
+			},
+			// IMPORT
+			{
+				ResourceName:            "lightdash_project.test_trino_project",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"clickhouse.0.password"},
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLightdashProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccLightdashProjectResourceDbtConnectionConfig(nameGitlab, "bitbucket", ""),
+				ExpectError: regexp.MustCompile("dbt_git.username is required"),
+			},
+			{
+				Config: testAccLightdashProjectResourceDbtConnectionConfig(nameGitlab, "gitlab", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashProjectExists("lightdash_project.test_git_project"),
+					resource.TestCheckResourceAttr("lightdash_project.test_git_project", "dbt_git.0.type", "gitlab"),
+				),
+			},
+			// MODIFY
+			{
+				Config: testAccLightdashProjectResourceDbtConnectionConfig(nameGitlab, "bitbucket", `username = "gthesheep"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashProjectExists("lightdash_project.test_git_project"),
+					resource.TestCheckResourceAttr("lightdash_project.test_git_project", "dbt_git.0.type", "bitbucket"),
+					resource.TestCheckResourceAttr("lightdash_project.test_git_project", "dbt_git.0.username", "gthesheep"),
+				),
+			},
+			// IMPORT
+			{
+				ResourceName:            "lightdash_project.test_git_project",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"dbt_git.0.personal_access_token"},
+			},
+			// MODIFY
+			{
+				Config: testAccLightdashProjectResourceDbtCloudConfig(nameGitlab),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashProjectExists("lightdash_project.test_git_project"),
+					resource.TestCheckResourceAttr("lightdash_project.test_git_project", "dbt_git.#", "0"),
+					resource.TestCheckResourceAttr("lightdash_project.test_git_project", "dbt_cloud_ide.0.environment_id", "12345"),
+					resource.TestCheckResourceAttr("lightdash_project.test_git_project", "dbt_cloud_ide.0.tags.#", "1"),
+				),
+			},
+			// IMPORT
+			{
+				ResourceName:            "lightdash_project.test_git_project",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"dbt_cloud_ide.0.api_key"},
+			},
+		},
+	})
+}
+
+func TestAccLightdashProjectDataSource(t *testing.T) {
+
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLightdashProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLightdashProjectDataSourceConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.lightdash_project.by_name", "project_uuid", "lightdash_project.test_project", "id"),
+					resource.TestCheckResourceAttrPair("data.lightdash_project.by_uuid", "name", "lightdash_project.test_project", "name"),
+					resource.TestCheckResourceAttr("data.lightdash_project.by_uuid", "type", "DEFAULT"),
+					resource.TestCheckResourceAttr("data.lightdash_project.by_uuid", "dbt_git.0.repository", "gthesheep/terraform-provider-dbt-cloud"),
+					resource.TestCheckResourceAttr("data.lightdash_project.by_uuid", "snowflake.0.warehouse", "TEST_WH"),
+					resource.TestCheckNoResourceAttr("data.lightdash_project.by_uuid", "snowflake.0.password"),
+					resource.TestCheckResourceAttr("data.lightdash_project.by_uuid", "databricks.#", "0"),
+					resource.TestCheckResourceAttr("data.lightdash_projects.matching", "projects.#", "1"),
+					resource.TestCheckResourceAttrPair("data.lightdash_projects.matching", "projects.0.project_uuid", "lightdash_project.test_project", "id"),
+					resource.TestCheckResourceAttr("data.lightdash_projects.matching", "projects.0.warehouse_type", "snowflake"),
+				),
+			},
+		},
+	})
+}
+
+func testAccLightdashProjectDataSourceConfig(name string) string {
+	return testAccLightdashProjectResourceBasicConfig(name) + fmt.Sprintf(`
+data "lightdash_project" "by_name" {
+    name = lightdash_project.test_project.name
+}
+
+data "lightdash_project" "by_uuid" {
+    project_uuid = lightdash_project.test_project.id
+}
+
+data "lightdash_projects" "matching" {
+    name_regex = "^%s$"
+    depends_on = [lightdash_project.test_project]
+}
+`, name)
 }
 
 func testAccLightdashProjectResourceBasicConfig(name string) string {
@@ -86,12 +283,15 @@ resource "lightdash_project" "test_project" {
     name = "%s"
     organization_uuid = data.lightdash_organization.test_org.organization_uuid
     type = "DEFAULT"
-    dbt_connection_repository = "gthesheep/terraform-provider-dbt-cloud"
-		warehouse_connection_type = "snowflake"
-    warehouse_connection_account = "abc-123.eu-west-1"
-    warehouse_connection_role = "ACCOUNTADMIN"
-    warehouse_connection_database = "DB"
-    warehouse_connection_warehouse = "TEST_WH"
+    dbt_git {
+        repository = "gthesheep/terraform-provider-dbt-cloud"
+    }
+    snowflake {
+        account = "abc-123.eu-west-1"
+        role = "ACCOUNTADMIN"
+        database = "DB"
+        warehouse = "TEST_WH"
+    }
 }
 `, name)
 }
@@ -105,12 +305,18 @@ resource "lightdash_project" "test_project" {
     name = "%s"
     organization_uuid = data.lightdash_organization.test_org.organization_uuid
     type = "DEFAULT"
-    dbt_connection_repository = "gthesheep/terraform-provider-dbt-cloud"
-		warehouse_connection_type = "snowflake"
-    warehouse_connection_account = "abc-123.eu-west-1"
-    warehouse_connection_role = "ACCOUNTADMIN"
-    warehouse_connection_database = "DB"
-    warehouse_connection_warehouse = "TEST_2_WH"
+    dbt_git {
+        repository = "gthesheep/terraform-provider-dbt-cloud"
+    }
+    snowflake {
+        account = "abc-123.eu-west-1"
+        role = "ACCOUNTADMIN"
+        database = "DB"
+        warehouse = "TEST_2_WH"
+        user = "LIGHTDASH"
+        password = "abcdefg123"
+        query_tag = "lightdash"
+    }
 }
 `, name)
 }
@@ -124,12 +330,15 @@ resource "lightdash_project" "test_databricks_project" {
     name = "%s"
     organization_uuid = data.lightdash_organization.test_org.organization_uuid
     type = "DEFAULT"
-    dbt_connection_repository = "gthesheep/terraform-provider-dbt-cloud"
-		warehouse_connection_type = "databricks"
-    databricks_connection_server_host_name = "help-im-on-databricks.com"
-    databricks_connection_http_path = "moo/baa"
-    databricks_connection_personal_access_token = "abcdefg123"
-    databricks_connection_catalog = "PROD"
+    dbt_git {
+        repository = "gthesheep/terraform-provider-dbt-cloud"
+    }
+    databricks {
+        server_host_name = "help-im-on-databricks.com"
+        http_path = "moo/baa"
+        personal_access_token = "abcdefg123"
+        catalog = "PROD"
+    }
 }
 `, name)
 }
@@ -143,12 +352,161 @@ resource "lightdash_project" "test_databricks_project" {
     name = "%s"
     organization_uuid = data.lightdash_organization.test_org.organization_uuid
     type = "DEFAULT"
-    dbt_connection_repository = "gthesheep/terraform-provider-dbt-cloud"
-		warehouse_connection_type = "databricks"
-    databricks_connection_server_host_name = "help-im-on-databricks.com"
-    databricks_connection_http_path = "moo/baa"
-    databricks_connection_personal_access_token = "abcdefg123"
-    databricks_connection_catalog = "DEV"
+    dbt_git {
+        repository = "gthesheep/terraform-provider-dbt-cloud"
+    }
+    databricks {
+        server_host_name = "help-im-on-databricks.com"
+        http_path = "moo/baa"
+        personal_access_token = "abcdefg123"
+        catalog = "DEV"
+        schema = "analytics"
+        start_of_week = "monday"
+    }
+}
+`, name)
+}
+
+func testAccLightdashProjectResourceBigqueryConfig(name, dataset string) string {
+	return fmt.Sprintf(`
+data "lightdash_organization" "test_org" {
+}
+
+resource "lightdash_project" "test_bigquery_project" {
+    name = "%s"
+    organization_uuid = data.lightdash_organization.test_org.organization_uuid
+    type = "DEFAULT"
+    dbt_git {
+        repository = "gthesheep/terraform-provider-dbt-cloud"
+    }
+    bigquery {
+        project = "my-gcp-project"
+        dataset = "%s"
+        location = "EU"
+        maximum_bytes_billed = 1000000000
+        keyfile_contents = jsonencode({
+            type = "service_account"
+            project_id = "my-gcp-project"
+        })
+    }
+}
+`, name, dataset)
+}
+
+func testAccLightdashProjectResourcePostgresConfig(name, warehouseType, extraConfig string) string {
+	return fmt.Sprintf(`
+data "lightdash_organization" "test_org" {
+}
+
+resource "lightdash_project" "test_postgres_project" {
+    name = "%s"
+    organization_uuid = data.lightdash_organization.test_org.organization_uuid
+    type = "DEFAULT"
+    dbt_git {
+        repository = "gthesheep/terraform-provider-dbt-cloud"
+    }
+    %s {
+        host = "db.example.com"
+        user = "lightdash"
+        password = "abcdefg123"
+        dbname = "analytics"
+        schema = "public"
+        %s
+    }
+}
+`, name, warehouseType, extraConfig)
+}
+
+func testAccLightdashProjectResourceTrinoConfig(name string) string {
+	return fmt.Sprintf(`
+data "lightdash_organization" "test_org" {
+}
+
+resource "lightdash_project" "test_trino_project" {
+    name = "%s"
+    organization_uuid = data.lightdash_organization.test_org.organization_uuid
+    type = "DEFAULT"
+    dbt_git {
+        repository = "gthesheep/terraform-provider-dbt-cloud"
+    }
+    trino {
+        host = "trino.example.com"
+        user = "lightdash"
+        password = "abcdefg123"
+        catalog = "hive"
+        schema = "analytics"
+    }
+}
+`, name)
+}
+
+func testAccLightdashProjectResourceClickhouseConfig(name string) string {
+	return fmt.Sprintf(`
+data "lightdash_organization" "test_org" {
+}
+
+resource "lightdash_project" "test_trino_project" {
+    name = "%s"
+    organization_uuid = data.lightdash_organization.test_org.organization_uuid
+    type = "DEFAULT"
+    dbt_git {
+        repository = "gthesheep/terraform-provider-dbt-cloud"
+    }
+    clickhouse {
+        host = "clickhouse.example.com"
+        user = "lightdash"
+        password = "abcdefg123"
+        schema = "events"
+    }
+}
+`, name)
+}
+
+func testAccLightdashProjectResourceDbtConnectionConfig(name, dbtConnectionType, extraConfig string) string {
+	return fmt.Sprintf(`
+data "lightdash_organization" "test_org" {
+}
+
+resource "lightdash_project" "test_git_project" {
+    name = "%s"
+    organization_uuid = data.lightdash_organization.test_org.organization_uuid
+    type = "DEFAULT"
+    dbt_git {
+        type = "%s"
+        repository = "gthesheep/terraform-provider-dbt-cloud"
+        personal_access_token = "abcdefg123"
+        %s
+    }
+    snowflake {
+        account = "abc-123.eu-west-1"
+        role = "ACCOUNTADMIN"
+        database = "DB"
+        warehouse = "TEST_WH"
+    }
+}
+`, name, dbtConnectionType, extraConfig)
+}
+
+func testAccLightdashProjectResourceDbtCloudConfig(name string) string {
+	return fmt.Sprintf(`
+data "lightdash_organization" "test_org" {
+}
+
+resource "lightdash_project" "test_git_project" {
+    name = "%s"
+    organization_uuid = data.lightdash_organization.test_org.organization_uuid
+    type = "DEFAULT"
+    dbt_cloud_ide {
+        api_key = "abcdefg123"
+        environment_id = "12345"
+        tags = ["lightdash"]
+    }
+    snowflake {
+        account = "abc-123.eu-west-1"
+        role = "ACCOUNTADMIN"
+        database = "DB"
+        warehouse = "TEST_WH"
+    }
 }
 `, name)
 }
@@ -163,7 +521,7 @@ func testAccCheckLightdashProjectExists(resource string) resource.TestCheckFunc
 			return fmt.Errorf("No Record ID is set")
 		}
 		apiClient := testAccProvider.Meta().(*lightdash.Client)
-		_, err := apiClient.GetProject(rs.Primary.ID)
+		_, err := apiClient.GetProject(context.Background(), rs.Primary.ID)
 		if err != nil {
 			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
 		}
@@ -179,7 +537,7 @@ func testAccCheckLightdashProjectDestroy(s *terraform.State) error {
 			continue
 		}
 
-		_, err := apiClient.GetProject(rs.Primary.ID)
+		_, err := apiClient.GetProject(context.Background(), rs.Primary.ID)
 		if err == nil {
 			return fmt.Errorf("Project still exists")
 		}
@@ -0,0 +1,186 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	projectRoles = []string{
+		"admin",
+		"developer",
+		"editor",
+		"interactive_viewer",
+		"viewer",
+	}
+)
+
+var projectAccessSchema = map[string]*schema.Schema{
+	"project_uuid": &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "UUID of the project to grant access to",
+	},
+	"user_uuid": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		Description:  "UUID of the user to grant access to, conflicts with `email`",
+		ExactlyOneOf: []string{"user_uuid", "email"},
+	},
+	"email": &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ForceNew:         true,
+		Description:      "Email address of the user to grant access to, conflicts with `user_uuid`",
+		ExactlyOneOf:     []string{"user_uuid", "email"},
+		DiffSuppressFunc: suppressEmailCaseDiff,
+	},
+	"role": &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		Description:  "Role for the user on the project, one of viewer/ interactive_viewer/ editor/ developer/ admin",
+		ValidateFunc: validation.StringInSlice(projectRoles, false),
+	},
+}
+
+func ResourceProjectAccess() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectAccessCreate,
+		ReadContext:   resourceProjectAccessRead,
+		UpdateContext: resourceProjectAccessUpdate,
+		DeleteContext: resourceProjectAccessDelete,
+
+		Schema: projectAccessSchema,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func splitProjectAccessID(id string) (string, string, error) {
+	parts := strings.Split(id, lightdash.ID_DELIMITER)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Unexpected format of ID (%s), expected <project_uuid>%s<user_uuid>", id, lightdash.ID_DELIMITER)
+	}
+	return parts[0], parts[1], nil
+}
+
+func resourceProjectAccessRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	projectUUID, userUUID, err := splitProjectAccessID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	projectMembers, err := c.GetProjectAccess(ctx, projectUUID)
+	if lightdash.IsNotFound(err) {
+		// The project has been deleted outside of Terraform
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var projectMember *lightdash.ProjectMember
+	for i := range projectMembers {
+		if projectMembers[i].UserUUID == userUUID {
+			projectMember = &projectMembers[i]
+		}
+	}
+	if projectMember == nil {
+		// The access has been removed outside of Terraform
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("project_uuid", projectUUID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("user_uuid", projectMember.UserUUID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("email", projectMember.Email); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role", projectMember.Role); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceProjectAccessCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	projectUUID := d.Get("project_uuid").(string)
+	email := d.Get("email").(string)
+	role := d.Get("role").(string)
+
+	// Access is granted by email, so look it up when only the UUID is given
+	if userUUID := d.Get("user_uuid").(string); userUUID != "" {
+		user, err := c.GetUser(ctx, userUUID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		email = user.Email
+	}
+
+	projectMember, err := c.CreateProjectAccess(ctx, projectUUID, email, role)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s%s%s", projectUUID, lightdash.ID_DELIMITER, projectMember.UserUUID))
+
+	return resourceProjectAccessRead(ctx, d, m)
+}
+
+func resourceProjectAccessUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	projectUUID, userUUID, err := splitProjectAccessID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("role") {
+		_, err := c.UpdateProjectAccess(ctx, projectUUID, userUUID, d.Get("role").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceProjectAccessRead(ctx, d, m)
+}
+
+func resourceProjectAccessDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	projectUUID, userUUID, err := splitProjectAccessID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	status, err := c.DeleteProjectAccess(ctx, projectUUID, userUUID)
+	if (status != "ok") || (err != nil) {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,107 @@
+package resources_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLightdashProjectAccessResource(t *testing.T) {
+
+	projectName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	email := "gthesheep@gmail.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLightdashProjectAccessDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLightdashProjectAccessResourceConfig(projectName, email, "viewer"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashProjectAccessExists("lightdash_project_access.test_project_access"),
+					resource.TestCheckResourceAttr("lightdash_project_access.test_project_access", "email", email),
+					resource.TestCheckResourceAttr("lightdash_project_access.test_project_access", "role", "viewer"),
+				),
+			},
+			// MODIFY
+			{
+				Config: testAccLightdashProjectAccessResourceConfig(projectName, email, "developer"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashProjectAccessExists("lightdash_project_access.test_project_access"),
+					resource.TestCheckResourceAttr("lightdash_project_access.test_project_access", "role", "developer"),
+				),
+			},
+			// IMPORT
+			{
+				ResourceName:            "lightdash_project_access.test_project_access",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{},
+			},
+		},
+	})
+}
+
+func testAccLightdashProjectAccessResourceConfig(projectName, email, role string) string {
+	return testAccLightdashSpaceProjectConfig(projectName) + fmt.Sprintf(`
+resource "lightdash_user" "test_user" {
+    email = "%s"
+}
+
+resource "lightdash_project_access" "test_project_access" {
+    project_uuid = lightdash_project.test_project.id
+    user_uuid = lightdash_user.test_user.id
+    role = "%s"
+}
+`, email, role)
+}
+
+func testAccCheckLightdashProjectAccessExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+		parts := strings.Split(rs.Primary.ID, lightdash.ID_DELIMITER)
+		apiClient := testAccProvider.Meta().(*lightdash.Client)
+		_, err := apiClient.GetProjectMember(context.Background(), parts[0], parts[1])
+		if err != nil {
+			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
+		}
+		return nil
+	}
+}
+
+func testAccCheckLightdashProjectAccessDestroy(s *terraform.State) error {
+	apiClient := testAccProvider.Meta().(*lightdash.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "lightdash_project_access" {
+			continue
+		}
+
+		parts := strings.Split(rs.Primary.ID, lightdash.ID_DELIMITER)
+		_, err := apiClient.GetProjectMember(context.Background(), parts[0], parts[1])
+		if err == nil {
+			return fmt.Errorf("Project access still exists")
+		}
+		notFoundErr := "not found"
+		expectedErr := regexp.MustCompile(notFoundErr)
+		if !expectedErr.Match([]byte(err.Error())) {
+			return fmt.Errorf("expected %s, got %s", notFoundErr, err)
+		}
+	}
+
+	return nil
+}
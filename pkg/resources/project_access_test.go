@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// handleProjectAccess serves /projects/{projectUuid}/access
+func (s *testLightdashServer) handleProjectAccess(w http.ResponseWriter, method string, parts []string, body []byte) {
+	projectUUID := parts[2]
+
+	switch method {
+	case "GET":
+		s.respond(w, s.access[projectUUID])
+	case "POST":
+		grant := map[string]interface{}{}
+		if err := json.Unmarshal(body, &grant); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Lightdash keeps the email as the user signed up with
+		for _, user := range s.users {
+			if strings.EqualFold(user["email"].(string), grant["email"].(string)) {
+				s.access[projectUUID] = append(s.access[projectUUID], map[string]interface{}{
+					"userUuid": user["userUuid"],
+					"email":    user["email"],
+					"role":     grant["role"],
+				})
+			}
+		}
+		s.respond(w, nil)
+	default:
+		http.Error(w, "unexpected request", http.StatusNotImplemented)
+	}
+}
+
+func TestResourceProjectAccessEmailCase(t *testing.T) {
+	server := newTestLightdashServer(t)
+	server.users = []map[string]interface{}{
+		{"userUuid": "user-1", "email": "jane.doe@example.com", "role": "viewer", "isActive": true},
+	}
+	client := server.client(t)
+	r := ResourceProjectAccess()
+
+	config := map[string]interface{}{
+		"project_uuid": "project-1",
+		"email":        "Jane.Doe@example.com",
+		"role":         "editor",
+	}
+	state := testResourceApply(t, r, nil, config, client)
+	if state.ID != "project-1:user-1" {
+		t.Fatalf("expected access to be granted to user-1, got %s", state.ID)
+	}
+	testResourcePlanIsEmpty(t, r, state, config, client)
+}
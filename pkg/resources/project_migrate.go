@@ -0,0 +1,579 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Version 0 of the project schema kept every connection setting as a flat
+// attribute, it is only used to decode existing state for the upgrade
+func resourceProjectV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: projectSchemaV0,
+	}
+}
+
+var projectSchemaV0 = map[string]*schema.Schema{
+	"organization_uuid": &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Sensitive:   true,
+		Description: "UUID of the organization to create the project in",
+	},
+	"name": &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Project name",
+	},
+	"type": &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		Description:  "Type of project to create, either DEFAULT or DEVELOPMENT",
+		ValidateFunc: validation.StringInSlice(projectTypes, false),
+	},
+	"dbt_version": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "v1.8",
+		Description: "dbt version, defaults to v1.8",
+	},
+	"dbt_connection_type": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "github",
+		Description:  "dbt project connection type, one of 'github', 'gitlab', 'bitbucket', 'azure_devops' or 'dbt_cloud_ide', 'github' is the default",
+		ValidateFunc: validation.StringInSlice(dbtConnectionTypes, false),
+	},
+	"dbt_connection_repository": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Repository name in <org>/<repo> format, or just the repository name for 'azure_devops', required for all git connection types",
+	},
+	"dbt_connection_branch": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "main",
+		Description: "Branch to use, default 'main'",
+	},
+	"dbt_connection_project_sub_path": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "/",
+		Description: "Sub path to find the project in the repo, default '/'",
+	},
+	"dbt_connection_host_domain": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Host domain of the repo, defaults to 'github.com', 'gitlab.com' or 'bitbucket.org' depending on the connection type, not used for 'azure_devops'",
+	},
+	"dbt_connection_personal_access_token": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Personal access token to authenticate with Git provider, or the app password for 'bitbucket'",
+	},
+	"dbt_connection_username": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Username to authenticate with, required for and only used by 'bitbucket'",
+	},
+	"dbt_connection_api_key": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Sensitive:   true,
+		Description: "dbt Cloud service token, required for and only used by 'dbt_cloud_ide', write-only and not read back from Lightdash",
+	},
+	"dbt_connection_environment_id": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "dbt Cloud environment ID, required for and only used by 'dbt_cloud_ide'",
+	},
+	"dbt_connection_discovery_api_endpoint": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "dbt Cloud discovery API endpoint, only used by 'dbt_cloud_ide', defaults to the dbt Cloud multi-tenant endpoint",
+	},
+	"dbt_connection_tags": &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "dbt Cloud tags used to filter the models to include, only used by 'dbt_cloud_ide'",
+	},
+	"dbt_connection_organization": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Organization the repo belongs to, required for and only used by 'azure_devops'",
+	},
+	"dbt_connection_project": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Project the repo belongs to, required for and only used by 'azure_devops'",
+	},
+	"warehouse_connection_type": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "snowflake",
+		Description:  "Type of warehouse to connect to, must be one of 'snowflake', 'databricks', 'bigquery', 'postgres', 'redshift', 'trino' or 'clickhouse', 'snowflake' is the default",
+		ValidateFunc: validation.StringInSlice(wareHouseTypes, false),
+	},
+	"databricks_connection_server_host_name": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Databricks - Server host name for connection",
+	},
+	"databricks_connection_http_path": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Databricks - HTTP path for connection",
+	},
+	"databricks_connection_personal_access_token": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Databricks - Personal access token for connection",
+	},
+	"databricks_connection_catalog": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Databricks - Catalog name for connection",
+	},
+	"databricks_connection_schema": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Databricks - Schema name for connection",
+	},
+	"bigquery_connection_project": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "BigQuery - GCP project ID to run queries in",
+	},
+	"bigquery_connection_dataset": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "BigQuery - Default dataset for the connection",
+	},
+	"bigquery_connection_location": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "BigQuery - Location of the dataset, e.g. 'EU' or 'us-central1'",
+	},
+	"bigquery_connection_priority": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "interactive",
+		Description:  "BigQuery - Query priority, one of 'interactive' or 'batch', default 'interactive'",
+		ValidateFunc: validation.StringInSlice(bigqueryPriorities, false),
+	},
+	"bigquery_connection_timeout_seconds": &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Default:     300,
+		Description: "BigQuery - Query timeout in seconds, default `300`",
+	},
+	"bigquery_connection_retries": &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Default:     3,
+		Description: "BigQuery - Number of times to retry a failed query, default `3`",
+	},
+	"bigquery_connection_maximum_bytes_billed": &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Description: "BigQuery - Maximum bytes a query can bill before failing, unlimited when not set",
+	},
+	"bigquery_connection_keyfile_contents": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		Description:  "BigQuery - Service account keyfile contents as a JSON string",
+		ValidateFunc: validation.StringIsJSON,
+	},
+	"postgres_connection_host": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Postgres/ Redshift - Host name of the database server",
+	},
+	"postgres_connection_port": &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Computed:    true,
+		Description: "Postgres/ Redshift - Port of the database server, defaults to `5432` for Postgres and `5439` for Redshift",
+	},
+	"postgres_connection_user": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Postgres/ Redshift - User to connect as",
+	},
+	"postgres_connection_password": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Sensitive:   true,
+		Description: "Postgres/ Redshift - Password for the user",
+	},
+	"postgres_connection_dbname": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Postgres/ Redshift - Database name to connect to",
+	},
+	"postgres_connection_schema": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Postgres/ Redshift - Schema to connect to",
+	},
+	"postgres_connection_sslmode": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "prefer",
+		Description:  "Postgres/ Redshift - SSL mode, one of disable/ no-verify/ allow/ prefer/ require/ verify-ca/ verify-full, default 'prefer'",
+		ValidateFunc: validation.StringInSlice(sslModes, false),
+	},
+	"postgres_connection_keepalives_idle": &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Default:     0,
+		Description: "Postgres/ Redshift - Seconds of inactivity before sending a keepalive, `0` uses the system default",
+	},
+	"postgres_connection_use_ssh_tunnel": &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Postgres/ Redshift - Connect through an SSH tunnel, default `false`",
+	},
+	"postgres_connection_ssh_tunnel_host": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Postgres/ Redshift - Host name of the SSH tunnel",
+	},
+	"postgres_connection_ssh_tunnel_port": &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Default:     22,
+		Description: "Postgres/ Redshift - Port of the SSH tunnel, default `22`",
+	},
+	"postgres_connection_ssh_tunnel_user": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Postgres/ Redshift - User to connect to the SSH tunnel as",
+	},
+	"postgres_connection_ssh_tunnel_public_key": &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Postgres/ Redshift - Public key generated by Lightdash, to be added to the SSH tunnel's authorized keys",
+	},
+	"redshift_connection_ra3_node": &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Redshift - Whether the cluster uses RA3 nodes, enabling cross-database queries, default `false`",
+	},
+	"trino_connection_host": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Trino - Host name of the coordinator",
+	},
+	"trino_connection_port": &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Default:     443,
+		Description: "Trino - Port of the coordinator, default `443`",
+	},
+	"trino_connection_user": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Trino - User to connect as",
+	},
+	"trino_connection_password": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Sensitive:   true,
+		Description: "Trino - Password for the user",
+	},
+	"trino_connection_catalog": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Trino - Catalog to connect to",
+	},
+	"trino_connection_schema": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Trino - Schema to connect to",
+	},
+	"trino_connection_http_scheme": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "https",
+		Description:  "Trino - HTTP scheme to connect with, one of 'https' or 'http', default 'https'",
+		ValidateFunc: validation.StringInSlice(httpSchemes, false),
+	},
+	"clickhouse_connection_host": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "ClickHouse - Host name of the server",
+	},
+	"clickhouse_connection_port": &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Default:     8443,
+		Description: "ClickHouse - HTTP(S) port of the server, default `8443`",
+	},
+	"clickhouse_connection_user": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "ClickHouse - User to connect as",
+	},
+	"clickhouse_connection_password": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Sensitive:   true,
+		Description: "ClickHouse - Password for the user",
+	},
+	"clickhouse_connection_schema": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "ClickHouse - Database to use as the schema",
+	},
+	"clickhouse_connection_secure": &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "ClickHouse - Connect over HTTPS, default `true`",
+	},
+	"clickhouse_connection_timeout_seconds": &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Default:     300,
+		Description: "ClickHouse - Query timeout in seconds, default `300`",
+	},
+	"warehouse_connection_account": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Snowflake - Account identifier, including region/ cloud path",
+	},
+	"warehouse_connection_role": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Snowflake - Role to connect to the warehouse with",
+	},
+	"warehouse_connection_database": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Snowflake - Database to connect to",
+	},
+	"warehouse_connection_schema": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "PUBLIC",
+		Description: "Snowflake - Schema to connect to, default 'PUBLIC'",
+	},
+	"warehouse_connection_client_session_keep_alive": &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Snowflake - Client session keep alive param, default `false`",
+	},
+	"warehouse_connection_warehouse": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Snowflake - Warehouse to use",
+	},
+	"warehouse_connection_threads": &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Default:     1,
+		Description: "Snowflake - Number of threads to use, default `1`",
+	},
+	"warehouse_connection_authentication_type": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "password",
+		Description:  "Snowflake - Authentication method, one of 'password', 'private_key' or 'sso' (OAuth), default 'password'",
+		ValidateFunc: validation.StringInSlice(snowflakeAuthenticationTypes, false),
+	},
+	"warehouse_connection_user": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Snowflake - User to connect as",
+	},
+	"warehouse_connection_password": &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		Description:   "Snowflake - Password for the user, write-only and not read back from Lightdash",
+		ConflictsWith: []string{"warehouse_connection_private_key"},
+	},
+	"warehouse_connection_private_key": &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		Description:   "Snowflake - PEM encoded private key for key-pair authentication, write-only and not read back from Lightdash",
+		ConflictsWith: []string{"warehouse_connection_password"},
+	},
+	"warehouse_connection_private_key_passphrase": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		Description:  "Snowflake - Passphrase for an encrypted private key, write-only and not read back from Lightdash",
+		RequiredWith: []string{"warehouse_connection_private_key"},
+	},
+	"warehouse_connection_query_tag": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Snowflake - Query tag added to all queries run by Lightdash",
+	},
+	"warehouse_connection_access_url": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Snowflake - Custom access URL, e.g. for private link connections",
+	},
+}
+
+// Attributes of each version 1 block, keyed by the version 0 attribute they
+// are moved from
+var projectV0Blocks = map[string]map[string]string{
+	"snowflake": {
+		"warehouse_connection_account":                   "account",
+		"warehouse_connection_role":                      "role",
+		"warehouse_connection_database":                  "database",
+		"warehouse_connection_warehouse":                 "warehouse",
+		"warehouse_connection_schema":                    "schema",
+		"warehouse_connection_client_session_keep_alive": "client_session_keep_alive",
+		"warehouse_connection_threads":                   "threads",
+		"warehouse_connection_authentication_type":       "authentication_type",
+		"warehouse_connection_user":                      "user",
+		"warehouse_connection_password":                  "password",
+		"warehouse_connection_private_key":               "private_key",
+		"warehouse_connection_private_key_passphrase":    "private_key_passphrase",
+		"warehouse_connection_query_tag":                 "query_tag",
+		"warehouse_connection_access_url":                "access_url",
+	},
+	"databricks": {
+		"databricks_connection_server_host_name":      "server_host_name",
+		"databricks_connection_http_path":             "http_path",
+		"databricks_connection_personal_access_token": "personal_access_token",
+		"databricks_connection_catalog":               "catalog",
+		"databricks_connection_schema":                "schema",
+	},
+	"bigquery": {
+		"bigquery_connection_project":              "project",
+		"bigquery_connection_dataset":              "dataset",
+		"bigquery_connection_location":             "location",
+		"bigquery_connection_priority":             "priority",
+		"bigquery_connection_timeout_seconds":      "timeout_seconds",
+		"bigquery_connection_retries":              "retries",
+		"bigquery_connection_maximum_bytes_billed": "maximum_bytes_billed",
+		"bigquery_connection_keyfile_contents":     "keyfile_contents",
+	},
+	"postgres": projectV0PostgresAttributes,
+	"redshift": projectV0RedshiftAttributes(),
+	"trino": {
+		"trino_connection_host":        "host",
+		"trino_connection_port":        "port",
+		"trino_connection_user":        "user",
+		"trino_connection_password":    "password",
+		"trino_connection_catalog":     "catalog",
+		"trino_connection_schema":      "schema",
+		"trino_connection_http_scheme": "http_scheme",
+	},
+	"clickhouse": {
+		"clickhouse_connection_host":            "host",
+		"clickhouse_connection_port":            "port",
+		"clickhouse_connection_user":            "user",
+		"clickhouse_connection_password":        "password",
+		"clickhouse_connection_schema":          "schema",
+		"clickhouse_connection_secure":          "secure",
+		"clickhouse_connection_timeout_seconds": "timeout_seconds",
+	},
+	"dbt_git": {
+		"dbt_connection_type":                  "type",
+		"dbt_connection_repository":            "repository",
+		"dbt_connection_branch":                "branch",
+		"dbt_connection_project_sub_path":      "project_sub_path",
+		"dbt_connection_host_domain":           "host_domain",
+		"dbt_connection_personal_access_token": "personal_access_token",
+		"dbt_connection_username":              "username",
+		"dbt_connection_organization":          "organization",
+		"dbt_connection_project":               "project",
+	},
+	"dbt_cloud_ide": {
+		"dbt_connection_api_key":                "api_key",
+		"dbt_connection_environment_id":         "environment_id",
+		"dbt_connection_discovery_api_endpoint": "discovery_api_endpoint",
+		"dbt_connection_tags":                   "tags",
+	},
+}
+
+var projectV0PostgresAttributes = map[string]string{
+	"postgres_connection_host":                  "host",
+	"postgres_connection_port":                  "port",
+	"postgres_connection_user":                  "user",
+	"postgres_connection_password":              "password",
+	"postgres_connection_dbname":                "dbname",
+	"postgres_connection_schema":                "schema",
+	"postgres_connection_sslmode":               "sslmode",
+	"postgres_connection_keepalives_idle":       "keepalives_idle",
+	"postgres_connection_use_ssh_tunnel":        "use_ssh_tunnel",
+	"postgres_connection_ssh_tunnel_host":       "ssh_tunnel_host",
+	"postgres_connection_ssh_tunnel_port":       "ssh_tunnel_port",
+	"postgres_connection_ssh_tunnel_user":       "ssh_tunnel_user",
+	"postgres_connection_ssh_tunnel_public_key": "ssh_tunnel_public_key",
+}
+
+func projectV0RedshiftAttributes() map[string]string {
+	attributes := map[string]string{
+		"redshift_connection_ra3_node": "ra3_node",
+	}
+	for from, to := range projectV0PostgresAttributes {
+		attributes[from] = to
+	}
+	return attributes
+}
+
+// Moves the flat connection attributes into the block matching the
+// warehouse and dbt connection types, attributes of other types are dropped
+func resourceProjectStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	warehouseBlock, _ := rawState["warehouse_connection_type"].(string)
+	if warehouseBlock == "" {
+		warehouseBlock = "snowflake"
+	}
+	dbtBlock := "dbt_git"
+	if dbtConnectionType, _ := rawState["dbt_connection_type"].(string); dbtConnectionType == "dbt_cloud_ide" {
+		dbtBlock = "dbt_cloud_ide"
+	}
+
+	upgradedState := map[string]interface{}{}
+	for key, value := range rawState {
+		if _, ok := projectSchemaV0[key]; !ok || projectSchema[key] != nil {
+			upgradedState[key] = value
+		}
+	}
+	for _, blockName := range []string{warehouseBlock, dbtBlock} {
+		block := map[string]interface{}{}
+		for from, to := range projectV0Blocks[blockName] {
+			if value, ok := rawState[from]; ok {
+				block[to] = value
+			}
+		}
+		upgradedState[blockName] = []interface{}{block}
+	}
+
+	// The host domain used to be computed, drop the default so it is not
+	// seen as configured
+	if dbtBlock == "dbt_git" {
+		block := upgradedState["dbt_git"].([]interface{})[0].(map[string]interface{})
+		connectionType, _ := block["type"].(string)
+		if connectionType == "" {
+			connectionType = "github"
+			block["type"] = connectionType
+		}
+		if hostDomain, _ := block["host_domain"].(string); hostDomain == dbtConnectionDefaultHostDomains[connectionType] {
+			block["host_domain"] = ""
+		}
+	}
+
+	return upgradedState, nil
+}
@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestResourceProjectStateUpgradeV0(t *testing.T) {
+	cases := map[string]struct {
+		rawState map[string]interface{}
+		expected map[string]interface{}
+	}{
+		"snowflake and github": {
+			rawState: map[string]interface{}{
+				"id":                                   "abc-123",
+				"name":                                 "Project",
+				"type":                                 "DEFAULT",
+				"dbt_version":                          "v1.8",
+				"dbt_connection_type":                  "github",
+				"dbt_connection_repository":            "org/repo",
+				"dbt_connection_branch":                "main",
+				"dbt_connection_host_domain":           "github.com",
+				"dbt_connection_personal_access_token": "token",
+				"dbt_connection_api_key":               "",
+				"warehouse_connection_type":            "snowflake",
+				"warehouse_connection_account":         "abc-123.eu-west-1",
+				"warehouse_connection_threads":         float64(4),
+				"warehouse_connection_password":        "secret",
+				"postgres_connection_host":             "",
+				"postgres_connection_ssh_tunnel_port":  float64(22),
+			},
+			expected: map[string]interface{}{
+				"id":          "abc-123",
+				"name":        "Project",
+				"type":        "DEFAULT",
+				"dbt_version": "v1.8",
+				"dbt_git": []interface{}{
+					map[string]interface{}{
+						"type":                  "github",
+						"repository":            "org/repo",
+						"branch":                "main",
+						"host_domain":           "",
+						"personal_access_token": "token",
+					},
+				},
+				"snowflake": []interface{}{
+					map[string]interface{}{
+						"account":  "abc-123.eu-west-1",
+						"threads":  float64(4),
+						"password": "secret",
+					},
+				},
+			},
+		},
+		"redshift and dbt cloud": {
+			rawState: map[string]interface{}{
+				"id":                                  "abc-123",
+				"dbt_connection_type":                 "dbt_cloud_ide",
+				"dbt_connection_repository":           "",
+				"dbt_connection_api_key":              "key",
+				"dbt_connection_environment_id":       "12345",
+				"dbt_connection_tags":                 []interface{}{"lightdash"},
+				"warehouse_connection_type":           "redshift",
+				"warehouse_connection_schema":         "PUBLIC",
+				"postgres_connection_host":            "db.example.com",
+				"postgres_connection_port":            float64(5439),
+				"postgres_connection_ssh_tunnel_port": float64(22),
+				"redshift_connection_ra3_node":        true,
+			},
+			expected: map[string]interface{}{
+				"id": "abc-123",
+				"dbt_cloud_ide": []interface{}{
+					map[string]interface{}{
+						"api_key":        "key",
+						"environment_id": "12345",
+						"tags":           []interface{}{"lightdash"},
+					},
+				},
+				"redshift": []interface{}{
+					map[string]interface{}{
+						"host":            "db.example.com",
+						"port":            float64(5439),
+						"ssh_tunnel_port": float64(22),
+						"ra3_node":        true,
+					},
+				},
+			},
+		},
+		"custom host domain": {
+			rawState: map[string]interface{}{
+				"id":                         "abc-123",
+				"dbt_connection_type":        "gitlab",
+				"dbt_connection_host_domain": "gitlab.example.com",
+			},
+			expected: map[string]interface{}{
+				"id": "abc-123",
+				"dbt_git": []interface{}{
+					map[string]interface{}{
+						"type":        "gitlab",
+						"host_domain": "gitlab.example.com",
+					},
+				},
+				"snowflake": []interface{}{
+					map[string]interface{}{},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := resourceProjectStateUpgradeV0(context.Background(), tc.rawState, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected:\n%#v\ngot:\n%#v", tc.expected, actual)
+			}
+		})
+	}
+}
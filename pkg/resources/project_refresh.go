@@ -0,0 +1,120 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var projectRefreshSchema = map[string]*schema.Schema{
+	"project_uuid": &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "UUID of the project to refresh",
+	},
+	"triggers": &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		ForceNew:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Arbitrary values that trigger a new refresh when changed, e.g. the commit SHA of the dbt project",
+	},
+	"job_uuid": &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "UUID of the refresh job",
+	},
+	"job_status": &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Final status of the refresh job, DONE when successful",
+	},
+	"duration_seconds": &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Time the refresh job took to finish, in seconds",
+	},
+}
+
+// Refreshing is an action rather than an object, so the resource only
+// records the last run and a new refresh is started when it is replaced
+func ResourceProjectRefresh() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectRefreshCreate,
+		ReadContext:   resourceProjectRefreshRead,
+		DeleteContext: resourceProjectRefreshDelete,
+
+		Schema: projectRefreshSchema,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+func resourceProjectRefreshRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	return diags
+}
+
+func resourceProjectRefreshCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	projectUUID := d.Get("project_uuid").(string)
+
+	startedAt := time.Now()
+	jobUUID, err := c.RefreshProject(ctx, projectUUID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Set before waiting, so a failed refresh is tainted and run again
+	d.SetId(jobUUID)
+	if err := d.Set("job_uuid", jobUUID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	job, jobDiags := waitForProjectJob(ctx, c, jobUUID, d.Timeout(schema.TimeoutCreate))
+	diags = append(diags, jobDiags...)
+	if job == nil {
+		return diags
+	}
+
+	if err := d.Set("job_status", job.JobStatus); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("duration_seconds", int(jobDuration(job, time.Since(startedAt)).Seconds())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// Uses the job timestamps when available, falling back to the time spent
+// waiting for the job
+func jobDuration(job *lightdash.Job, waited time.Duration) time.Duration {
+	createdAt, err := time.Parse(time.RFC3339, job.CreatedAt)
+	if err != nil {
+		return waited
+	}
+	updatedAt, err := time.Parse(time.RFC3339, job.UpdatedAt)
+	if err != nil || updatedAt.Before(createdAt) {
+		return waited
+	}
+	return updatedAt.Sub(createdAt)
+}
+
+func resourceProjectRefreshDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Nothing to undo, the refresh is only removed from state
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,76 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// handleProjectRefresh serves /projects/{projectUuid}/refresh
+func (s *testLightdashServer) handleProjectRefresh(w http.ResponseWriter, method string) {
+	if method != "POST" {
+		http.Error(w, "unexpected request", http.StatusNotImplemented)
+		return
+	}
+	s.respond(w, map[string]interface{}{"jobUuid": s.startJob()})
+}
+
+func TestResourceProjectRefresh(t *testing.T) {
+	server := newTestLightdashServer(t)
+	client := server.client(t)
+	r := ResourceProjectRefresh()
+
+	config := map[string]interface{}{
+		"project_uuid": "project-1",
+		"triggers": map[string]interface{}{
+			"commit": "abc123",
+		},
+	}
+	state := testResourceApply(t, r, nil, config, client)
+	testResourcePlanIsEmpty(t, r, state, config, client)
+
+	if state.ID != "job-1" || state.Attributes["job_uuid"] != "job-1" {
+		t.Errorf("expected the job UUID to be the ID, got %v", state.Attributes)
+	}
+	if state.Attributes["job_status"] != "DONE" {
+		t.Errorf("expected the job to be done, got %s", state.Attributes["job_status"])
+	}
+	if state.Attributes["duration_seconds"] != "90" {
+		t.Errorf("expected the duration from the job timestamps, got %s", state.Attributes["duration_seconds"])
+	}
+
+	// Changing a trigger starts a new refresh
+	config["triggers"] = map[string]interface{}{
+		"commit": "def456",
+	}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+	if err != nil {
+		t.Fatalf("unexpected error planning: %s", err)
+	}
+	if !diff.RequiresNew() {
+		t.Fatalf("expected a changed trigger to replace the refresh")
+	}
+}
+
+func TestResourceProjectRefreshFailure(t *testing.T) {
+	server := newTestLightdashServer(t)
+	server.failJobs = true
+	client := server.client(t)
+	r := ResourceProjectRefresh()
+
+	config := map[string]interface{}{
+		"project_uuid": "project-1",
+	}
+	state, diags := testResourceApplyDiags(t, r, nil, config, client)
+	if !diags.HasError() {
+		t.Fatalf("expected the failed refresh to be reported")
+	}
+	if diags[0].Detail != "Failed to compile project\nCompilation Error in model orders" {
+		t.Errorf("unexpected detail: %s", diags[0].Detail)
+	}
+	if state == nil || state.ID != "job-1" || state.Attributes["job_status"] != "ERROR" {
+		t.Errorf("expected the failed job to be kept in state, got %v", state)
+	}
+}
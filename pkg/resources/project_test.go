@@ -0,0 +1,383 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Fields Lightdash never returns once they have been saved
+var testSensitiveConnectionFields = []string{
+	"api_key",
+	"keyfileContents",
+	"oauthClientSecret",
+	"password",
+	"personalAccessToken",
+	"personal_access_token",
+	"privateKey",
+	"privateKeyPass",
+	"user",
+}
+
+// handleProject serves /org/projects, /org/projects/{projectUuid} and
+// /projects/{projectUuid}
+func (s *testLightdashServer) handleProject(w http.ResponseWriter, method string, parts []string, body []byte) {
+	switch {
+	case method == "GET" && len(parts) == 3 && parts[1] == "org":
+		s.respond(w, []interface{}{})
+	case method == "POST" && len(parts) == 3 && parts[1] == "org":
+		project := map[string]interface{}{}
+		if err := json.Unmarshal(body, &project); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		project["projectUuid"] = fmt.Sprintf("project-%d", len(s.projects)+1)
+		s.projects[project["projectUuid"].(string)] = project
+		s.respond(w, map[string]interface{}{"project": withoutSecrets(project), "hasContentCopy": false})
+	case method == "DELETE" && len(parts) == 4 && parts[1] == "org":
+		delete(s.projects, parts[3])
+		s.respond(w, nil)
+	case method == "GET" && len(parts) == 3:
+		project, ok := s.projects[parts[2]]
+		if !ok {
+			http.Error(w, `{"status":"error","error":{"statusCode":404,"name":"NotFoundError","message":"Project not found"}}`, http.StatusNotFound)
+			return
+		}
+		s.respond(w, withoutSecrets(project))
+	case method == "PATCH" && len(parts) == 3:
+		project, ok := s.projects[parts[2]]
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		update := map[string]interface{}{}
+		if err := json.Unmarshal(body, &update); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for key, value := range update {
+			project[key] = value
+		}
+		s.respond(w, map[string]interface{}{"jobUuid": s.startJob()})
+	default:
+		http.Error(w, "unexpected request", http.StatusNotImplemented)
+	}
+}
+
+// handleJob serves /jobs/{jobUuid}
+func (s *testLightdashServer) handleJob(w http.ResponseWriter, method string, parts []string) {
+	if method != "GET" || len(parts) != 3 {
+		http.Error(w, "unexpected request", http.StatusNotImplemented)
+		return
+	}
+	s.respond(w, s.pollJob(parts[2]))
+}
+
+func (s *testLightdashServer) startJob() string {
+	jobUUID := fmt.Sprintf("job-%d", len(s.jobPolls)+1)
+	s.jobPolls[jobUUID] = 0
+	return jobUUID
+}
+
+func (s *testLightdashServer) pollJob(jobUUID string) map[string]interface{} {
+	s.jobPolls[jobUUID]++
+	job := map[string]interface{}{
+		"jobUuid":   jobUUID,
+		"jobStatus": lightdash.JobStatusRunning,
+		"jobType":   "COMPILE_PROJECT",
+		"createdAt": "2024-05-01T10:00:00.000Z",
+		"updatedAt": "2024-05-01T10:00:05.000Z",
+		"steps":     []interface{}{},
+	}
+	if s.jobPolls[jobUUID] < 2 {
+		return job
+	}
+	job["jobStatus"] = lightdash.JobStatusDone
+	job["updatedAt"] = "2024-05-01T10:01:30.000Z"
+	if s.failJobs {
+		job["jobStatus"] = lightdash.JobStatusError
+		job["steps"] = []interface{}{
+			map[string]interface{}{
+				"stepType":   "COMPILING",
+				"stepStatus": lightdash.JobStatusError,
+				"stepLabel":  "Compiling dbt project",
+				"stepError":  "Failed to compile project",
+				"stepDbtLogs": []interface{}{
+					map[string]interface{}{"level": "info", "msg": "Running with dbt=1.8.0"},
+					map[string]interface{}{"level": "error", "msg": "Compilation Error in model orders"},
+				},
+			},
+		}
+	}
+	return job
+}
+
+func (s *testLightdashServer) warehouseConnection(projectUUID string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.projects[projectUUID]["warehouseConnection"].(map[string]interface{})
+}
+
+func withoutSecrets(project map[string]interface{}) map[string]interface{} {
+	response := map[string]interface{}{}
+	for key, value := range project {
+		connection, ok := value.(map[string]interface{})
+		if !ok {
+			response[key] = value
+			continue
+		}
+		redacted := map[string]interface{}{}
+		for field, fieldValue := range connection {
+			redacted[field] = fieldValue
+		}
+		for _, field := range testSensitiveConnectionFields {
+			delete(redacted, field)
+		}
+		response[key] = redacted
+	}
+	return response
+}
+
+func testProjectConfig(warehouseType string, warehouse map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name":              "Project",
+		"organization_uuid": "organization-1",
+		"type":              "DEFAULT",
+		"dbt_git": []interface{}{
+			map[string]interface{}{
+				"repository":            "gthesheep/terraform-provider-dbt-cloud",
+				"personal_access_token": "abcdefg123",
+			},
+		},
+		warehouseType: []interface{}{warehouse},
+	}
+}
+
+func testProjectDatabricksConfig(databricks map[string]interface{}) map[string]interface{} {
+	return testProjectConfig("databricks", databricks)
+}
+
+func TestResourceProjectDatabricks(t *testing.T) {
+	server := newTestLightdashServer(t)
+	client := server.client(t)
+	r := ResourceProject()
+
+	config := testProjectDatabricksConfig(map[string]interface{}{
+		"server_host_name":      "help-im-on-databricks.com",
+		"http_path":             "moo/baa",
+		"personal_access_token": "abcdefg123",
+		"catalog":               "PROD",
+		"schema":                "analytics",
+	})
+	state := testResourceApply(t, r, nil, config, client)
+	testResourcePlanIsEmpty(t, r, state, config, client)
+
+	warehouseConnection := server.warehouseConnection(state.ID)
+	if warehouseConnection["database"] != "analytics" {
+		t.Errorf("expected the schema to be sent as the database, got %v", warehouseConnection)
+	}
+	if warehouseConnection["personalAccessToken"] != "abcdefg123" {
+		t.Errorf("expected the personal access token to be sent, got %v", warehouseConnection)
+	}
+
+	// Switch to a service principal and add compute
+	config = testProjectDatabricksConfig(map[string]interface{}{
+		"server_host_name":    "help-im-on-databricks.com",
+		"http_path":           "moo/baa",
+		"authentication_type": "oauth_m2m",
+		"oauth_client_id":     "client-id",
+		"oauth_client_secret": "client-secret",
+		"catalog":             "DEV",
+		"schema":              "marts",
+		"start_of_week":       "sunday",
+		"compute": []interface{}{
+			map[string]interface{}{"name": "Small", "http_path": "sql/small"},
+			map[string]interface{}{"name": "Large", "http_path": "sql/large"},
+		},
+	})
+	state = testResourceApply(t, r, state, config, client)
+	testResourcePlanIsEmpty(t, r, state, config, client)
+
+	warehouseConnection = server.warehouseConnection(state.ID)
+	if warehouseConnection["database"] != "marts" || warehouseConnection["catalog"] != "DEV" {
+		t.Errorf("expected the catalog and schema to be updated, got %v", warehouseConnection)
+	}
+	if warehouseConnection["oauthClientSecret"] != "client-secret" || warehouseConnection["personalAccessToken"] != nil {
+		t.Errorf("expected only the client credentials to be sent, got %v", warehouseConnection)
+	}
+	if warehouseConnection["startOfWeek"] != float64(6) {
+		t.Errorf("expected sunday to be sent as 6, got %v", warehouseConnection["startOfWeek"])
+	}
+	if compute := warehouseConnection["compute"].([]interface{}); len(compute) != 2 {
+		t.Errorf("expected 2 compute entries, got %v", compute)
+	}
+	if state.Attributes["databricks.0.compute.1.http_path"] != "sql/large" {
+		t.Errorf("expected compute to be read back, got %v", state.Attributes)
+	}
+}
+
+// The user is not returned by Lightdash, so it must be kept from state
+// rather than being emptied on every refresh
+func TestResourceProjectWarehouseUser(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"snowflake": {
+			"account":   "xy12345",
+			"role":      "TRANSFORMER",
+			"database":  "ANALYTICS",
+			"warehouse": "COMPUTE_WH",
+			"schema":    "PUBLIC",
+			"user":      "LIGHTDASH",
+			"password":  "snowflake-password",
+		},
+		"postgres": {
+			"host":     "postgres.example.com",
+			"user":     "lightdash",
+			"password": "postgres-password",
+			"dbname":   "analytics",
+			"schema":   "public",
+		},
+		"redshift": {
+			"host":     "redshift.example.com",
+			"user":     "lightdash",
+			"password": "redshift-password",
+			"dbname":   "analytics",
+			"schema":   "public",
+		},
+		"trino": {
+			"host":     "trino.example.com",
+			"user":     "lightdash",
+			"password": "trino-password",
+			"catalog":  "hive",
+			"schema":   "analytics",
+		},
+		"clickhouse": {
+			"host":     "clickhouse.example.com",
+			"user":     "lightdash",
+			"password": "clickhouse-password",
+			"schema":   "analytics",
+		},
+	}
+
+	for warehouseType, warehouse := range cases {
+		t.Run(warehouseType, func(t *testing.T) {
+			server := newTestLightdashServer(t)
+			client := server.client(t)
+			r := ResourceProject()
+
+			config := testProjectConfig(warehouseType, warehouse)
+			state := testResourceApply(t, r, nil, config, client)
+			state, diags := r.RefreshWithoutUpgrade(context.Background(), state, client)
+			if diags.HasError() {
+				t.Fatalf("unexpected error refreshing: %v", diags)
+			}
+
+			if user := server.warehouseConnection(state.ID)["user"]; user != warehouse["user"] {
+				t.Errorf("expected the user to be sent, got %v", user)
+			}
+			if user := state.Attributes[warehouseType+".0.user"]; user != warehouse["user"] {
+				t.Errorf("expected the user to be kept in state, got %q", user)
+			}
+		})
+	}
+}
+
+func TestResourceProjectDatabricksOAuthRequiresClientID(t *testing.T) {
+	server := newTestLightdashServer(t)
+	client := server.client(t)
+	r := ResourceProject()
+
+	config := testProjectDatabricksConfig(map[string]interface{}{
+		"server_host_name":    "help-im-on-databricks.com",
+		"http_path":           "moo/baa",
+		"authentication_type": "oauth_m2m",
+	})
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), client)
+	if err == nil || !strings.Contains(err.Error(), "oauth_client_id") {
+		t.Fatalf("expected an error about the missing client ID, got %v", err)
+	}
+}
+
+func TestResourceProjectWaitForCompile(t *testing.T) {
+	server := newTestLightdashServer(t)
+	client := server.client(t)
+	r := ResourceProject()
+
+	config := testProjectDatabricksConfig(map[string]interface{}{
+		"server_host_name":      "help-im-on-databricks.com",
+		"http_path":             "moo/baa",
+		"personal_access_token": "abcdefg123",
+	})
+	config["wait_for_compile"] = true
+	state := testResourceApply(t, r, nil, config, client)
+	testResourcePlanIsEmpty(t, r, state, config, client)
+
+	if server.jobPolls["job-1"] != 2 {
+		t.Errorf("expected the compile job to be polled until done, got %d polls", server.jobPolls["job-1"])
+	}
+
+	// A failing compile is reported with the dbt errors
+	server.failJobs = true
+	config["databricks"].([]interface{})[0].(map[string]interface{})["catalog"] = "DEV"
+	_, diags := testResourceApplyDiags(t, r, state, config, client)
+	if !diags.HasError() {
+		t.Fatalf("expected the failed compile to be reported")
+	}
+	if diags[0].Summary != "Project job step failed: Compiling dbt project" {
+		t.Errorf("unexpected summary: %s", diags[0].Summary)
+	}
+	if diags[0].Detail != "Failed to compile project\nCompilation Error in model orders" {
+		t.Errorf("unexpected detail: %s", diags[0].Detail)
+	}
+}
+
+func TestResourceProjectWithoutWaitForCompile(t *testing.T) {
+	server := newTestLightdashServer(t)
+	client := server.client(t)
+	r := ResourceProject()
+
+	config := testProjectDatabricksConfig(map[string]interface{}{
+		"server_host_name":      "help-im-on-databricks.com",
+		"http_path":             "moo/baa",
+		"personal_access_token": "abcdefg123",
+	})
+	state := testResourceApply(t, r, nil, config, client)
+	config["databricks"].([]interface{})[0].(map[string]interface{})["catalog"] = "DEV"
+	testResourceApply(t, r, state, config, client)
+
+	for _, request := range server.requests {
+		if strings.Contains(request, "/jobs/") || strings.HasSuffix(request, "/refresh") {
+			t.Errorf("unexpected request when not waiting for compile: %s", request)
+		}
+	}
+}
+
+func TestResourceProjectDeletedOutsideTerraform(t *testing.T) {
+	server := newTestLightdashServer(t)
+	client := server.client(t)
+	r := ResourceProject()
+
+	config := testProjectDatabricksConfig(map[string]interface{}{
+		"server_host_name":      "help-im-on-databricks.com",
+		"http_path":             "moo/baa",
+		"personal_access_token": "abcdefg123",
+	})
+	state := testResourceApply(t, r, nil, config, client)
+
+	server.mu.Lock()
+	delete(server.projects, state.ID)
+	server.mu.Unlock()
+
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), state, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error refreshing: %v", diags)
+	}
+	if state != nil {
+		t.Fatalf("expected the project to be removed from state, got %v", state)
+	}
+}
@@ -0,0 +1,157 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var spaceSchema = map[string]*schema.Schema{
+	"project_uuid": &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "UUID of the project to create the space in",
+	},
+	"name": &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Space name",
+	},
+	"is_private": &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether the space is private to the users it is shared with, default `false`",
+	},
+	"parent_space_uuid": &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "UUID of the parent space, to create this space nested inside another",
+	},
+	"space_uuid": &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "UUID of the space",
+	},
+}
+
+func ResourceSpace() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSpaceCreate,
+		ReadContext:   resourceSpaceRead,
+		UpdateContext: resourceSpaceUpdate,
+		DeleteContext: resourceSpaceDelete,
+
+		Schema: spaceSchema,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func splitSpaceID(id string) (string, string, error) {
+	parts := strings.Split(id, lightdash.ID_DELIMITER)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Unexpected format of ID (%s), expected <project_uuid>%s<space_uuid>", id, lightdash.ID_DELIMITER)
+	}
+	return parts[0], parts[1], nil
+}
+
+func resourceSpaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	projectUUID, spaceUUID, err := splitSpaceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	space, err := c.GetSpace(ctx, projectUUID, spaceUUID)
+	if lightdash.IsNotFound(err) {
+		// The space has been deleted outside of Terraform
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("project_uuid", projectUUID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("space_uuid", space.SpaceUUID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", space.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_private", space.IsPrivate); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("parent_space_uuid", space.ParentSpaceUUID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceSpaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	projectUUID := d.Get("project_uuid").(string)
+	name := d.Get("name").(string)
+	isPrivate := d.Get("is_private").(bool)
+	parentSpaceUUID := d.Get("parent_space_uuid").(string)
+
+	space, err := c.CreateSpace(ctx, projectUUID, name, isPrivate, parentSpaceUUID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s%s%s", projectUUID, lightdash.ID_DELIMITER, space.SpaceUUID))
+
+	return resourceSpaceRead(ctx, d, m)
+}
+
+func resourceSpaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	projectUUID, spaceUUID, err := splitSpaceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("name") || d.HasChange("is_private") {
+		_, err := c.UpdateSpace(ctx, projectUUID, spaceUUID, d.Get("name").(string), d.Get("is_private").(bool))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceSpaceRead(ctx, d, m)
+}
+
+func resourceSpaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	projectUUID, spaceUUID, err := splitSpaceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	status, err := c.DeleteSpace(ctx, projectUUID, spaceUUID)
+	if (status != "ok") || (err != nil) {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,144 @@
+package resources_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLightdashSpaceResource(t *testing.T) {
+
+	projectName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	name2 := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLightdashSpaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLightdashSpaceResourceBasicConfig(projectName, name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashSpaceExists("lightdash_space.test_space"),
+					resource.TestCheckResourceAttr("lightdash_space.test_space", "name", name),
+					resource.TestCheckResourceAttr("lightdash_space.test_space", "is_private", "false"),
+				),
+			},
+			// MODIFY
+			{
+				Config: testAccLightdashSpaceResourceFullConfig(projectName, name2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashSpaceExists("lightdash_space.test_space"),
+					testAccCheckLightdashSpaceExists("lightdash_space.test_child_space"),
+					resource.TestCheckResourceAttr("lightdash_space.test_space", "name", name2),
+					resource.TestCheckResourceAttr("lightdash_space.test_space", "is_private", "true"),
+					resource.TestCheckResourceAttrPair("lightdash_space.test_child_space", "parent_space_uuid", "lightdash_space.test_space", "space_uuid"),
+				),
+			},
+			// IMPORT
+			{
+				ResourceName:            "lightdash_space.test_space",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{},
+			},
+		},
+	})
+}
+
+func testAccLightdashSpaceProjectConfig(projectName string) string {
+	return fmt.Sprintf(`
+data "lightdash_organization" "test_org" {
+}
+
+resource "lightdash_project" "test_project" {
+    name = "%s"
+    organization_uuid = data.lightdash_organization.test_org.organization_uuid
+    type = "DEFAULT"
+    dbt_git {
+        repository = "gthesheep/terraform-provider-dbt-cloud"
+    }
+    snowflake {
+        account = "abc-123.eu-west-1"
+        role = "ACCOUNTADMIN"
+        database = "DB"
+        warehouse = "TEST_WH"
+    }
+}
+`, projectName)
+}
+
+func testAccLightdashSpaceResourceBasicConfig(projectName, name string) string {
+	return testAccLightdashSpaceProjectConfig(projectName) + fmt.Sprintf(`
+resource "lightdash_space" "test_space" {
+    project_uuid = lightdash_project.test_project.id
+    name = "%s"
+}
+`, name)
+}
+
+func testAccLightdashSpaceResourceFullConfig(projectName, name string) string {
+	return testAccLightdashSpaceProjectConfig(projectName) + fmt.Sprintf(`
+resource "lightdash_space" "test_space" {
+    project_uuid = lightdash_project.test_project.id
+    name = "%s"
+    is_private = true
+}
+
+resource "lightdash_space" "test_child_space" {
+    project_uuid = lightdash_project.test_project.id
+    name = "%s-child"
+    parent_space_uuid = lightdash_space.test_space.space_uuid
+}
+`, name, name)
+}
+
+func testAccCheckLightdashSpaceExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+		parts := strings.Split(rs.Primary.ID, lightdash.ID_DELIMITER)
+		apiClient := testAccProvider.Meta().(*lightdash.Client)
+		_, err := apiClient.GetSpace(context.Background(), parts[0], parts[1])
+		if err != nil {
+			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
+		}
+		return nil
+	}
+}
+
+func testAccCheckLightdashSpaceDestroy(s *terraform.State) error {
+	apiClient := testAccProvider.Meta().(*lightdash.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "lightdash_space" {
+			continue
+		}
+
+		parts := strings.Split(rs.Primary.ID, lightdash.ID_DELIMITER)
+		_, err := apiClient.GetSpace(context.Background(), parts[0], parts[1])
+		if err == nil {
+			return fmt.Errorf("Space still exists")
+		}
+		notFoundErr := "not found"
+		expectedErr := regexp.MustCompile(notFoundErr)
+		if !expectedErr.Match([]byte(err.Error())) {
+			return fmt.Errorf("expected %s, got %s", notFoundErr, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	spaceRoles = []string{
+		"admin",
+		"editor",
+		"viewer",
+	}
+)
+
+var spaceAccessSchema = map[string]*schema.Schema{
+	"project_uuid": &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "UUID of the project the space belongs to",
+	},
+	"space_uuid": &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "UUID of the space to share",
+	},
+	"user_uuid": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		Description:  "UUID of the user to share the space with, conflicts with `group_uuid`",
+		ExactlyOneOf: []string{"user_uuid", "group_uuid"},
+	},
+	"group_uuid": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		Description:  "UUID of the group to share the space with, conflicts with `user_uuid`",
+		ExactlyOneOf: []string{"user_uuid", "group_uuid"},
+	},
+	"role": &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "viewer",
+		Description:  "Access level on the space, one of viewer/ editor/ admin",
+		ValidateFunc: validation.StringInSlice(spaceRoles, false),
+	},
+}
+
+func ResourceSpaceAccess() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSpaceAccessCreate,
+		ReadContext:   resourceSpaceAccessRead,
+		UpdateContext: resourceSpaceAccessUpdate,
+		DeleteContext: resourceSpaceAccessDelete,
+
+		Schema: spaceAccessSchema,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// The ID is made up of <project_uuid>:<space_uuid>:<user|group>:<uuid>
+func splitSpaceAccessID(id string) (string, string, string, string, error) {
+	parts := strings.Split(id, lightdash.ID_DELIMITER)
+	if len(parts) != 4 || (parts[2] != "user" && parts[2] != "group") {
+		return "", "", "", "", fmt.Errorf("Unexpected format of ID (%s), expected <project_uuid>:<space_uuid>:user:<user_uuid> or <project_uuid>:<space_uuid>:group:<group_uuid>", id)
+	}
+	return parts[0], parts[1], parts[2], parts[3], nil
+}
+
+func resourceSpaceAccessRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	projectUUID, spaceUUID, granteeType, granteeUUID, err := splitSpaceAccessID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	space, err := c.GetSpace(ctx, projectUUID, spaceUUID)
+	if lightdash.IsNotFound(err) {
+		// The space has been deleted outside of Terraform
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only direct shares are managed here, access inherited from the
+	// project or organization role is ignored
+	role := ""
+	if granteeType == "user" {
+		for _, access := range space.Access {
+			if access.UserUUID == granteeUUID && access.HasDirectAccess {
+				role = access.Role
+			}
+		}
+	} else {
+		for _, access := range space.GroupsAccess {
+			if access.GroupUUID == granteeUUID {
+				role = access.SpaceRole
+			}
+		}
+	}
+
+	if role == "" {
+		// The share has been removed outside of Terraform
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("project_uuid", projectUUID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("space_uuid", spaceUUID); err != nil {
+		return diag.FromErr(err)
+	}
+	if granteeType == "user" {
+		if err := d.Set("user_uuid", granteeUUID); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		if err := d.Set("group_uuid", granteeUUID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("role", role); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceSpaceAccessCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	projectUUID := d.Get("project_uuid").(string)
+	spaceUUID := d.Get("space_uuid").(string)
+	userUUID := d.Get("user_uuid").(string)
+	groupUUID := d.Get("group_uuid").(string)
+	role := d.Get("role").(string)
+
+	granteeType := "user"
+	granteeUUID := userUUID
+	var err error
+	if userUUID != "" {
+		_, err = c.ShareSpaceWithUser(ctx, projectUUID, spaceUUID, userUUID, role)
+	} else {
+		granteeType = "group"
+		granteeUUID = groupUUID
+		_, err = c.ShareSpaceWithGroup(ctx, projectUUID, spaceUUID, groupUUID, role)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strings.Join([]string{projectUUID, spaceUUID, granteeType, granteeUUID}, lightdash.ID_DELIMITER))
+
+	return resourceSpaceAccessRead(ctx, d, m)
+}
+
+func resourceSpaceAccessUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	projectUUID, spaceUUID, granteeType, granteeUUID, err := splitSpaceAccessID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("role") {
+		role := d.Get("role").(string)
+		if granteeType == "user" {
+			_, err = c.ShareSpaceWithUser(ctx, projectUUID, spaceUUID, granteeUUID, role)
+		} else {
+			_, err = c.ShareSpaceWithGroup(ctx, projectUUID, spaceUUID, granteeUUID, role)
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceSpaceAccessRead(ctx, d, m)
+}
+
+func resourceSpaceAccessDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*lightdash.Client)
+
+	var diags diag.Diagnostics
+
+	projectUUID, spaceUUID, granteeType, granteeUUID, err := splitSpaceAccessID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var status string
+	if granteeType == "user" {
+		status, err = c.UnshareSpaceWithUser(ctx, projectUUID, spaceUUID, granteeUUID)
+	} else {
+		status, err = c.UnshareSpaceWithGroup(ctx, projectUUID, spaceUUID, granteeUUID)
+	}
+	if (status != "ok") || (err != nil) {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,113 @@
+package resources_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLightdashSpaceAccessResource(t *testing.T) {
+
+	projectName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	spaceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	email := "gthesheep@gmail.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLightdashSpaceAccessDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLightdashSpaceAccessResourceConfig(projectName, spaceName, email, "viewer"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashSpaceAccessExists("lightdash_space_access.test_space_access"),
+					resource.TestCheckResourceAttr("lightdash_space_access.test_space_access", "role", "viewer"),
+				),
+			},
+			// MODIFY
+			{
+				Config: testAccLightdashSpaceAccessResourceConfig(projectName, spaceName, email, "editor"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashSpaceAccessExists("lightdash_space_access.test_space_access"),
+					resource.TestCheckResourceAttr("lightdash_space_access.test_space_access", "role", "editor"),
+				),
+			},
+			// IMPORT
+			{
+				ResourceName:            "lightdash_space_access.test_space_access",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{},
+			},
+		},
+	})
+}
+
+func testAccLightdashSpaceAccessResourceConfig(projectName, spaceName, email, role string) string {
+	return testAccLightdashSpaceResourceFullConfig(projectName, spaceName) + fmt.Sprintf(`
+resource "lightdash_user" "test_user" {
+    email = "%s"
+}
+
+resource "lightdash_space_access" "test_space_access" {
+    project_uuid = lightdash_project.test_project.id
+    space_uuid = lightdash_space.test_space.space_uuid
+    user_uuid = lightdash_user.test_user.id
+    role = "%s"
+}
+`, email, role)
+}
+
+func testAccCheckLightdashSpaceAccessExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+		parts := strings.Split(rs.Primary.ID, lightdash.ID_DELIMITER)
+		apiClient := testAccProvider.Meta().(*lightdash.Client)
+		space, err := apiClient.GetSpace(context.Background(), parts[0], parts[1])
+		if err != nil {
+			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
+		}
+		for _, access := range space.Access {
+			if access.UserUUID == parts[3] && access.HasDirectAccess {
+				return nil
+			}
+		}
+		return fmt.Errorf("Space %s is not shared with user %s", parts[1], parts[3])
+	}
+}
+
+func testAccCheckLightdashSpaceAccessDestroy(s *terraform.State) error {
+	apiClient := testAccProvider.Meta().(*lightdash.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "lightdash_space_access" {
+			continue
+		}
+
+		parts := strings.Split(rs.Primary.ID, lightdash.ID_DELIMITER)
+		space, err := apiClient.GetSpace(context.Background(), parts[0], parts[1])
+		if err != nil {
+			// The space has been removed along with its shares
+			continue
+		}
+		for _, access := range space.Access {
+			if access.UserUUID == parts[3] && access.HasDirectAccess {
+				return fmt.Errorf("Space access still exists")
+			}
+		}
+	}
+
+	return nil
+}
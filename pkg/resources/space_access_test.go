@@ -0,0 +1,123 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// handleSpace serves /projects/{projectUuid}/spaces/{spaceUuid}, and the
+// group shares below it
+func (s *testLightdashServer) handleSpace(w http.ResponseWriter, method string, parts []string, body []byte) {
+	space, ok := s.spaces[parts[4]]
+	if !ok {
+		http.Error(w, `{"status":"error","error":{"statusCode":404,"name":"NotFoundError","message":"Space not found"}}`, http.StatusNotFound)
+		return
+	}
+	groupsAccess, _ := space["groupsAccess"].([]interface{})
+
+	switch {
+	case method == "GET" && len(parts) == 5:
+		s.respond(w, space)
+	case method == "POST" && len(parts) == 7 && parts[5] == "group" && parts[6] == "share":
+		share := map[string]interface{}{}
+		if err := json.Unmarshal(body, &share); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		space["groupsAccess"] = append(withoutGroupAccess(groupsAccess, share["groupUuid"]), map[string]interface{}{
+			"groupUuid": share["groupUuid"],
+			"spaceRole": share["spaceRole"],
+		})
+		s.respond(w, nil)
+	case method == "DELETE" && len(parts) == 8 && parts[5] == "group" && parts[6] == "share":
+		space["groupsAccess"] = withoutGroupAccess(groupsAccess, parts[7])
+		s.respond(w, nil)
+	default:
+		http.Error(w, "unexpected request", http.StatusNotImplemented)
+	}
+}
+
+func withoutGroupAccess(groupsAccess []interface{}, groupUUID interface{}) []interface{} {
+	remaining := []interface{}{}
+	for _, access := range groupsAccess {
+		if access.(map[string]interface{})["groupUuid"] != groupUUID {
+			remaining = append(remaining, access)
+		}
+	}
+	return remaining
+}
+
+func (s *testLightdashServer) spaceGroupsAccess(spaceUUID string) []interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	groupsAccess, _ := s.spaces[spaceUUID]["groupsAccess"].([]interface{})
+	return groupsAccess
+}
+
+func TestResourceSpaceAccessGroup(t *testing.T) {
+	server := newTestLightdashServer(t)
+	server.spaces["space-1"] = map[string]interface{}{
+		"uuid":        "space-1",
+		"name":        "Finance",
+		"projectUuid": "project-1",
+		"isPrivate":   true,
+		"access":      []interface{}{},
+	}
+	client := server.client(t)
+	r := ResourceSpaceAccess()
+
+	config := map[string]interface{}{
+		"project_uuid": "project-1",
+		"space_uuid":   "space-1",
+		"group_uuid":   "group-1",
+		"role":         "editor",
+	}
+	state := testResourceApply(t, r, nil, config, client)
+	if state.ID != "project-1:space-1:group:group-1" {
+		t.Fatalf("unexpected ID: %s", state.ID)
+	}
+	testResourcePlanIsEmpty(t, r, state, config, client)
+
+	// Changing the role shares the space again
+	config["role"] = "admin"
+	state = testResourceApply(t, r, state, config, client)
+	testResourcePlanIsEmpty(t, r, state, config, client)
+	groupsAccess := server.spaceGroupsAccess("space-1")
+	if len(groupsAccess) != 1 || groupsAccess[0].(map[string]interface{})["spaceRole"] != "admin" {
+		t.Errorf("expected the group to be an admin of the space, got %v", groupsAccess)
+	}
+
+	state, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error deleting: %v", diags)
+	}
+	if state != nil {
+		t.Errorf("expected the share to be removed from state, got %v", state)
+	}
+	if groupsAccess := server.spaceGroupsAccess("space-1"); len(groupsAccess) != 0 {
+		t.Errorf("expected the space to be unshared, got %v", groupsAccess)
+	}
+}
+
+func TestResourceSpaceAccessGroupRemovedOutsideTerraform(t *testing.T) {
+	server := newTestLightdashServer(t)
+	server.spaces["space-1"] = map[string]interface{}{
+		"uuid":         "space-1",
+		"projectUuid":  "project-1",
+		"groupsAccess": []interface{}{},
+	}
+	client := server.client(t)
+	r := ResourceSpaceAccess()
+
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), &terraform.InstanceState{ID: "project-1:space-1:group:group-1"}, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error refreshing: %v", diags)
+	}
+	if state != nil {
+		t.Errorf("expected the share to be removed from state, got %v", state)
+	}
+}
@@ -2,6 +2,8 @@ package resources
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -9,31 +11,28 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-var (
-	roles = []string{
-		"admin",
-		"editor",
-		"viewer",
-	}
-)
-
 var userSchema = map[string]*schema.Schema{
 	"email": &schema.Schema{
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "User email address to send invite link to",
+		Type:             schema.TypeString,
+		Required:         true,
+		Description:      "User email address to send invite link to",
+		DiffSuppressFunc: suppressEmailCaseDiff,
 	},
 	"role": &schema.Schema{
 		Type:         schema.TypeString,
 		Optional:     true,
 		Default:      "viewer",
-		Description:  "Type of role for the user, one of viewer/ editor/ admin",
-		ValidateFunc: validation.StringInSlice(roles, false),
+		Description:  "Type of role for the user, one of member/ viewer/ interactive_viewer/ editor/ developer/ admin",
+		ValidateFunc: validation.StringInSlice(lightdash.OrganizationRoles, false),
+		// Roles this provider doesn't know are not managed, see resourceUserRead
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			return old != "" && !validRole(old)
+		},
 	},
 	"invite_code": &schema.Schema{
 		Type:        schema.TypeString,
 		Computed:    true,
-		Description: "Original invite code for the user",
+		Description: "Original invite code for the user, empty for imported users",
 	},
 }
 
@@ -46,7 +45,7 @@ func ResourceUser() *schema.Resource {
 
 		Schema: userSchema,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceUserImport,
 		},
 	}
 }
@@ -59,7 +58,12 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	userID := d.Id()
 
-	user, err := c.GetUser(userID)
+	user, err := c.GetUser(ctx, userID)
+	if lightdash.IsNotFound(err) {
+		// The user has been deleted outside of Terraform
+		d.SetId("")
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -67,13 +71,59 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	if err := d.Set("email", user.Email); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("role", user.Role); err != nil {
-		return diag.FromErr(err)
+	// An unknown role is only written when importing, otherwise the role from
+	// state is kept rather than writing a value the schema would reject
+	if validRole(user.Role) || d.Get("role").(string) == "" {
+		if err := d.Set("role", user.Role); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if !validRole(user.Role) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unknown user role",
+			Detail:   fmt.Sprintf("User %s has role %q which is not supported by this provider version, the role will not be managed until the provider is upgraded", user.Email, user.Role),
+		})
 	}
 
 	return diags
 }
 
+// resourceUserImport accepts either the user UUID or their email address
+func resourceUserImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*lightdash.Client)
+
+	if strings.Contains(d.Id(), "@") {
+		user, err := c.GetUserByEmail(ctx, d.Id())
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(user.UserUUID)
+	}
+
+	// The invite code is only returned when creating the invite
+	if err := d.Set("invite_code", ""); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// suppressEmailCaseDiff ignores case changes to emails, as Lightdash matches
+// them case-insensitively
+func suppressEmailCaseDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+func validRole(role string) bool {
+	for _, r := range lightdash.OrganizationRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*lightdash.Client)
 
@@ -82,7 +132,7 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 	email := d.Get("email").(string)
 	role := d.Get("role").(string)
 
-	inviteLink, err := c.CreateInviteLink(email)
+	inviteLink, err := c.CreateInviteLink(ctx, email)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -96,7 +146,7 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
-	user, err := c.UpdateUser(newUser.UserUUID, role)
+	user, err := c.UpdateUser(ctx, newUser.UserUUID, role)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -117,7 +167,7 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	userID := d.Id()
 
 	if d.HasChange("role") {
-		_, err := c.UpdateUser(userID, d.Get("role").(string))
+		_, err := c.UpdateUser(ctx, userID, d.Get("role").(string))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -132,14 +182,20 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface
 
 	var diags diag.Diagnostics
 
+	// Imported users have no invite code, and deleting an empty invite code
+	// would revoke every invite link of the organization
 	inviteCode := d.Get("invite_code").(string)
-	status, err := c.DeleteInviteLink(inviteCode)
-	if (status != "ok") || (err != nil) {
-		status, err := c.DeleteUser(userID)
-		if (status != "ok") || (err != nil) {
-			return diag.FromErr(err)
+	if inviteCode != "" {
+		status, err := c.DeleteInviteLink(ctx, inviteCode)
+		if (status == "ok") && (err == nil) {
+			return diags
 		}
 	}
 
+	status, err := c.DeleteUser(ctx, userID)
+	if (status != "ok") || (err != nil) {
+		return diag.FromErr(err)
+	}
+
 	return diags
 }
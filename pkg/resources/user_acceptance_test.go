@@ -1,8 +1,10 @@
 package resources_test
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/gthesheep/terraform-provider-lightdash/pkg/lightdash"
@@ -15,6 +17,7 @@ func TestAccLightdashUserResource(t *testing.T) {
 
 	email := "gthesheep@gmail.com"
 	role := "editor"
+	role2 := "interactive_viewer"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -37,6 +40,14 @@ func TestAccLightdashUserResource(t *testing.T) {
 					resource.TestCheckResourceAttr("lightdash_user.test_user", "role", role),
 				),
 			},
+			// MODIFY
+			{
+				Config: testAccLightdashUserResourceFullConfig(email, role2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLightdashUserExists("lightdash_user.test_user"),
+					resource.TestCheckResourceAttr("lightdash_user.test_user", "role", role2),
+				),
+			},
 			// IMPORT
 			{
 				ResourceName:            "lightdash_user.test_user",
@@ -48,6 +59,30 @@ func TestAccLightdashUserResource(t *testing.T) {
 	})
 }
 
+func TestAccLightdashUserDataSource(t *testing.T) {
+
+	email := "gthesheep@gmail.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLightdashUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLightdashUserDataSourceConfig(email),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.lightdash_user.by_email", "user_uuid", "lightdash_user.test_user", "id"),
+					resource.TestCheckResourceAttrPair("data.lightdash_user.by_uuid", "email", "lightdash_user.test_user", "email"),
+					resource.TestCheckResourceAttr("data.lightdash_user.by_uuid", "role", "editor"),
+					resource.TestCheckResourceAttr("data.lightdash_user.by_uuid", "is_active", "false"),
+					resource.TestCheckResourceAttr("data.lightdash_users.invited_editors", "users.#", "1"),
+					resource.TestCheckResourceAttrPair("data.lightdash_users.invited_editors", "users.0.user_uuid", "lightdash_user.test_user", "id"),
+				),
+			},
+		},
+	})
+}
+
 func testAccLightdashUserResourceBasicConfig(email string) string {
 	return fmt.Sprintf(`
 resource "lightdash_user" "test_user" {
@@ -65,6 +100,25 @@ resource "lightdash_user" "test_user" {
 `, email, role)
 }
 
+func testAccLightdashUserDataSourceConfig(email string) string {
+	return testAccLightdashUserResourceFullConfig(email, "editor") + fmt.Sprintf(`
+data "lightdash_user" "by_email" {
+    email = upper(lightdash_user.test_user.email)
+}
+
+data "lightdash_user" "by_uuid" {
+    user_uuid = lightdash_user.test_user.id
+}
+
+data "lightdash_users" "invited_editors" {
+    role = "editor"
+    is_active = false
+    email_domain_regex = "^%s$"
+    depends_on = [lightdash_user.test_user]
+}
+`, regexp.QuoteMeta(email[strings.LastIndex(email, "@")+1:]))
+}
+
 func testAccCheckLightdashUserExists(resource string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
@@ -75,7 +129,7 @@ func testAccCheckLightdashUserExists(resource string) resource.TestCheckFunc {
 			return fmt.Errorf("No Record ID is set")
 		}
 		apiClient := testAccProvider.Meta().(*lightdash.Client)
-		_, err := apiClient.GetUser(rs.Primary.ID)
+		_, err := apiClient.GetUser(context.Background(), rs.Primary.ID)
 		if err != nil {
 			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
 		}
@@ -92,13 +146,13 @@ func testAccCheckLightdashUserDestroy(s *terraform.State) error {
 		}
 
 		if rs.Primary.Attributes["invite_code"] != "" {
-			_, err := apiClient.GetInviteLink(rs.Primary.Attributes["invite_code"])
+			_, err := apiClient.GetInviteLink(context.Background(), rs.Primary.Attributes["invite_code"])
 			if err == nil {
 				return fmt.Errorf("Invite link still exists")
 			}
 		}
 
-		_, err := apiClient.GetUser(rs.Primary.ID)
+		_, err := apiClient.GetUser(context.Background(), rs.Primary.ID)
 		if err == nil {
 			return fmt.Errorf("User still exists")
 		}
@@ -0,0 +1,157 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// handleUser serves /org/users and /org/user/{userUuid}
+func (s *testLightdashServer) handleUser(w http.ResponseWriter, method string, parts []string) {
+	switch {
+	case method == "GET" && len(parts) == 3:
+		s.respond(w, s.users)
+	case method == "DELETE" && len(parts) == 4:
+		remaining := []map[string]interface{}{}
+		for _, user := range s.users {
+			if user["userUuid"] != parts[3] {
+				remaining = append(remaining, user)
+			}
+		}
+		s.users = remaining
+		s.respond(w, nil)
+	default:
+		http.Error(w, "unexpected request", http.StatusNotImplemented)
+	}
+}
+
+func TestResourceUserImport(t *testing.T) {
+	cases := map[string]string{
+		"by uuid":  "user-2",
+		"by email": "Jane.Doe@example.com",
+	}
+
+	for name, importID := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := newTestLightdashServer(t)
+			server.users = []map[string]interface{}{
+				{"userUuid": "user-1", "email": "john@example.com", "role": "viewer", "isActive": true},
+				{"userUuid": "user-2", "email": "jane.doe@example.com", "role": "editor", "isActive": true},
+			}
+			client := server.client(t)
+			r := ResourceUser()
+
+			d := r.Data(&terraform.InstanceState{ID: importID})
+			imported, err := r.Importer.StateContext(context.Background(), d, client)
+			if err != nil {
+				t.Fatalf("unexpected error importing: %s", err)
+			}
+			if len(imported) != 1 || imported[0].Id() != "user-2" {
+				t.Fatalf("expected user-2 to be imported, got %v", imported)
+			}
+
+			state := imported[0].State()
+			config := map[string]interface{}{
+				"email": "Jane.Doe@example.com",
+				"role":  "editor",
+			}
+			testResourcePlanIsEmpty(t, r, state, config, client)
+		})
+	}
+}
+
+func TestResourceUserImportUnknownRole(t *testing.T) {
+	server := newTestLightdashServer(t)
+	server.users = []map[string]interface{}{
+		{"userUuid": "user-1", "email": "jane.doe@example.com", "role": "owner", "isActive": true},
+	}
+	client := server.client(t)
+	r := ResourceUser()
+
+	d := r.Data(&terraform.InstanceState{ID: "user-1"})
+	imported, err := r.Importer.StateContext(context.Background(), d, client)
+	if err != nil {
+		t.Fatalf("unexpected error importing: %s", err)
+	}
+
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), imported[0].State(), client)
+	if len(diags) != 1 || diags[0].Summary != "Unknown user role" {
+		t.Errorf("expected a warning about the unknown role, got %v", diags)
+	}
+	if role := state.Attributes["role"]; role != "owner" {
+		t.Errorf("expected the role to be imported, got %q", role)
+	}
+
+	// The role is left alone rather than changed to the configured one
+	config := map[string]interface{}{
+		"email": "jane.doe@example.com",
+	}
+	testResourcePlanIsEmpty(t, r, state, config, client)
+}
+
+func TestResourceUserImportUnknownEmail(t *testing.T) {
+	server := newTestLightdashServer(t)
+	client := server.client(t)
+	r := ResourceUser()
+
+	d := r.Data(&terraform.InstanceState{ID: "nobody@example.com"})
+	if _, err := r.Importer.StateContext(context.Background(), d, client); err == nil {
+		t.Fatal("expected an error importing an unknown email")
+	}
+}
+
+func TestResourceUserDeletedOutsideTerraform(t *testing.T) {
+	server := newTestLightdashServer(t)
+	client := server.client(t)
+	r := ResourceUser()
+
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), &terraform.InstanceState{ID: "user-1"}, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error refreshing: %v", diags)
+	}
+	if state != nil {
+		t.Fatalf("expected the user to be removed from state, got %v", state)
+	}
+}
+
+func TestResourceUserDeleteImported(t *testing.T) {
+	server := newTestLightdashServer(t)
+	server.users = []map[string]interface{}{
+		{"userUuid": "user-1", "email": "jane.doe@example.com", "role": "editor", "isActive": true},
+	}
+	client := server.client(t)
+	r := ResourceUser()
+
+	d := r.Data(&terraform.InstanceState{ID: "jane.doe@example.com"})
+	imported, err := r.Importer.StateContext(context.Background(), d, client)
+	if err != nil {
+		t.Fatalf("unexpected error importing: %s", err)
+	}
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), imported[0].State(), client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error refreshing: %v", diags)
+	}
+
+	destroy := &terraform.InstanceDiff{Destroy: true}
+	if _, diags := r.Apply(context.Background(), state, destroy, client); diags.HasError() {
+		t.Fatalf("unexpected error destroying: %v", diags)
+	}
+
+	// An imported user has no invite code, so must not revoke the invite
+	// links of the whole organization
+	deleted := false
+	for _, request := range server.requests {
+		if strings.Contains(request, "/invite-links") {
+			t.Errorf("unexpected invite link request %s", request)
+		}
+		if request == "DELETE /api/v1/org/user/user-1" {
+			deleted = true
+		}
+	}
+	if !deleted {
+		t.Errorf("expected the user to be deleted, got %v", server.requests)
+	}
+}